@@ -0,0 +1,115 @@
+package swag
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// generalAttributeNames are the canonical names of the general API info attributes.
+var generalAttributeNames = []string{
+	"@title", "@version", "@description", "@description.markdown", "@termsOfService",
+	"@contact.name", "@contact.url", "@contact.email", "@license.name", "@license.url",
+	"@host", "@HostState", "@BasePath", "@accept", "@produce", "@schemes", "@query.collection.format",
+	"@tag.name", "@tag.description", "@tag.description.markdown", "@tag.docs.url", "@tag.docs.description",
+	"@securityDefinitions.basic", "@securityDefinitions.apikey", "@securityDefinitions.oauth2.application",
+	"@securityDefinitions.oauth2.implicit", "@securityDefinitions.oauth2.password",
+	"@securityDefinitions.oauth2.accessCode", "@in", "@name", "@tokenUrl", "@authorizationUrl",
+	"@security", "@externalDocs.description", "@externalDocs.url", "@param.define", "@response.define",
+	"@Template",
+}
+
+// OperationAttributeNames returns the canonical names of the attributes of operations.
+func OperationAttributeNames() []string {
+	return slices.Clone(operationAttributeNames)
+}
+
+// GeneralAttributeNames returns the canonical names of the attributes of the general API
+// info.
+func GeneralAttributeNames() []string {
+	return slices.Clone(generalAttributeNames)
+}
+
+// maxAttributeDistance is the largest edit distance between an unknown attribute and a
+// known one for the latter to be suggested.
+const maxAttributeDistance = 2
+
+// isKnownAttribute reports whether the attribute is one of the names, ignoring case, or
+// an extension.
+func isKnownAttribute(attribute string, names []string) bool {
+	lowerAttribute := strings.ToLower(attribute)
+	if strings.HasPrefix(lowerAttribute, "@x-") ||
+		strings.HasPrefix(lowerAttribute, "@tag.x-") ||
+		strings.HasPrefix(lowerAttribute, scopeAttrPrefix) {
+		return true
+	}
+
+	for _, name := range names {
+		if strings.ToLower(name) == lowerAttribute {
+			return true
+		}
+	}
+
+	return false
+}
+
+// suggestAttribute returns the name closest to the misspelled attribute, empty if none
+// is close enough.
+func suggestAttribute(attribute string, names []string) string {
+	var (
+		suggestion string
+		best       = maxAttributeDistance + 1
+	)
+
+	for _, name := range names {
+		distance := editDistance(strings.ToLower(attribute), strings.ToLower(name))
+		if distance < best {
+			suggestion, best = name, distance
+		}
+	}
+
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
+
+// checkAttribute reports an attribute which is none of the names, with the closest of
+// the suggested names. It is an error in strict mode, and a warning otherwise.
+func (parser *Parser) checkAttribute(position token.Position, attribute string, names, suggested []string) error {
+	if parser == nil || !strings.HasPrefix(attribute, "@") || isKnownAttribute(attribute, names) {
+		return nil
+	}
+
+	err := fmt.Errorf("unknown attribute %s", attribute)
+	if suggestion := suggestAttribute(attribute, suggested); suggestion != "" {
+		err = fmt.Errorf("unknown attribute %s, did you mean %s?", attribute, suggestion)
+	}
+
+	return parser.warn(position, UnknownAttributeCode, err)
+}
@@ -0,0 +1,72 @@
+package swag
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestAttribute(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "@Success", suggestAttribute("@Sucess", operationAttributeNames))
+	assert.Equal(t, "@Param", suggestAttribute("@Parm", operationAttributeNames))
+	assert.Equal(t, "@Router", suggestAttribute("@router", operationAttributeNames))
+	assert.Equal(t, "@license.name", suggestAttribute("@licence.name", generalAttributeNames))
+	assert.Empty(t, suggestAttribute("@author", operationAttributeNames))
+}
+
+func TestEditDistance(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, editDistance("@param", "@param"))
+	assert.Equal(t, 1, editDistance("@sucess", "@success"))
+	assert.Equal(t, 2, editDistance("@prodcue", "@produce"))
+	assert.Equal(t, 6, editDistance("", "@param"))
+}
+
+func TestOperation_ParseCommentUnknownAttribute(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	operation := NewOperation(New(SetDebugger(log.New(&buf, "", 0))))
+	assert.NoError(t, operation.ParseComment("@Sucess 200 {string} string", nil))
+	assert.NoError(t, operation.ParseComment("@author John", nil))
+	assert.NoError(t, operation.ParseComment("@x-custom {\"a\": 1}", nil))
+	assert.NoError(t, operation.ParseComment("@title Swagger Example API", nil))
+	assert.Equal(t, "warning: unknown attribute @Sucess, did you mean @Success?\n"+
+		"warning: unknown attribute @author\n", buf.String())
+	assert.Empty(t, operation.Responses.StatusCodeResponses)
+
+	operation = NewOperation(New(SetStrict(true)))
+	assert.EqualError(t, operation.ParseComment("@Parm id path int true \"ID\"", nil),
+		"unknown attribute @Parm, did you mean @Param?")
+
+	// operations without a parser don't check their attributes
+	assert.NoError(t, NewOperation(nil).ParseComment("@Sucess 200", nil))
+}
+
+func TestParseGeneralAPIInfoUnknownAttribute(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	comments := []string{
+		"@title Swagger Example API",
+		"@licence.name Apache 2.0",
+		"@Summary Used by the handlers of the main file",
+		"@tag.name users",
+		"@tag.x-displayName Users",
+	}
+
+	parser := New(SetDebugger(log.New(&buf, "", 0)))
+	assert.NoError(t, parseGeneralAPIInfo(parser, comments))
+	assert.Equal(t, "warning: unknown attribute @licence.name, did you mean @license.name?\n", buf.String())
+
+	parser = New(SetStrict(true))
+	assert.EqualError(t, parseGeneralAPIInfo(parser, comments),
+		"unknown attribute @licence.name, did you mean @license.name?")
+}
@@ -0,0 +1,176 @@
+package swag
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// blockMarker ends an attribute line whose description is the block of the comment lines
+// following it, up to the next attribute. The indentation of the block is removed, and
+// its lines are kept as they are, so it may hold Markdown. E.g.
+//
+//	@Description |
+//	  Lists the users, **paginated**:
+//
+//	  - by name
+//	  - by role
+//	@Param name query string false |
+//	  The name of the users, or a prefix of it.
+const blockMarker = "|"
+
+// isBlockAttribute reports whether the comment line is an attribute ended by the block
+// marker.
+func isBlockAttribute(line string) bool {
+	line = strings.TrimSpace(strings.TrimLeft(line, "/"))
+	if !strings.HasPrefix(line, "@") {
+		return false
+	}
+
+	fields := strings.Fields(line)
+
+	return len(fields) > 1 && fields[len(fields)-1] == blockMarker
+}
+
+// isBlockLine reports whether the comment line may belong to a block: it is neither an
+// attribute nor a directive like //swag:nolint.
+func isBlockLine(line string) bool {
+	if text, ok := strings.CutPrefix(line, "//"); ok && text != "" && text[0] != ' ' && text[0] != '\t' {
+		return false
+	}
+
+	return !strings.HasPrefix(strings.TrimSpace(strings.TrimLeft(line, "/")), "@")
+}
+
+// joinBlockLines returns the comment lines with the lines of each block joined to its
+// attribute line, separated by newlines, and the index of the first line of each of them.
+func joinBlockLines(lines []string) ([]string, []int) {
+	joined := make([]string, 0, len(lines))
+	starts := make([]int, 0, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		line, start := lines[i], i
+
+		if isBlockAttribute(line) {
+			for i+1 < len(lines) && isBlockLine(lines[i+1]) {
+				i++
+				line += "\n" + lines[i]
+			}
+		}
+
+		joined = append(joined, line)
+		starts = append(starts, start)
+	}
+
+	return joined, starts
+}
+
+// joinCommentBlocks returns the comments with the lines of each block joined to the
+// comment of its attribute, which keeps its position.
+func joinCommentBlocks(comments []*ast.Comment) []*ast.Comment {
+	lines := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		lines = append(lines, comment.Text)
+	}
+
+	joined, starts := joinBlockLines(lines)
+	if len(joined) == len(comments) {
+		return comments
+	}
+
+	joinedComments := make([]*ast.Comment, 0, len(joined))
+	for i, text := range joined {
+		joinedComments = append(joinedComments, &ast.Comment{Slash: comments[starts[i]].Slash, Text: text})
+	}
+
+	return joinedComments
+}
+
+// cutCommentBlock returns the attribute line of a comment joined with its block, and the
+// text of the block, without its indentation nor its leading and trailing blank lines.
+func cutCommentBlock(comment string) (string, string) {
+	line, rest, found := strings.Cut(comment, "\n")
+	if !found {
+		return comment, ""
+	}
+
+	return line, commentText(strings.Split(rest, "\n"))
+}
+
+// commentText returns the text of comment lines, without their comment markers, their
+// common indentation nor the leading and trailing blank lines.
+func commentText(commentLines []string) string {
+	lines := make([]string, len(commentLines))
+	for i, line := range commentLines {
+		line = strings.TrimRight(line, " \t\r")
+		if text, ok := strings.CutPrefix(line, "//"); ok {
+			line = text
+		}
+
+		lines[i] = line
+	}
+
+	// the indentation is the longest one common to the lines which are not blank
+	var indent *string
+
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		lineIndent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if indent == nil {
+			indent = &lineIndent
+		}
+
+		for !strings.HasPrefix(lineIndent, *indent) {
+			*indent = (*indent)[:len(*indent)-1]
+		}
+	}
+
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	for i := range lines {
+		if indent != nil {
+			lines[i] = strings.TrimPrefix(lines[i], *indent)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// cutBlockMarker returns the comment line without the block marker ending it, and
+// whether it had one.
+func cutBlockMarker(commentLine string) (string, bool) {
+	fields := strings.Fields(commentLine)
+	if len(fields) < 2 || fields[len(fields)-1] != blockMarker {
+		return commentLine, false
+	}
+
+	return strings.TrimRight(strings.TrimSuffix(strings.TrimRight(commentLine, " \t"), blockMarker), " \t"), true
+}
+
+// quoteBlockMarker quotes the block marker ending a comment line, so that the patterns of
+// quoted descriptions match it.
+func quoteBlockMarker(commentLine string) string {
+	if line, ok := cutBlockMarker(commentLine); ok {
+		return line + ` "` + blockMarker + `"`
+	}
+
+	return commentLine
+}
+
+// blockDescription returns the block of the comment being parsed when the description
+// is the block marker, and the description otherwise.
+func (operation *Operation) blockDescription(description string) string {
+	if description == blockMarker {
+		return operation.commentBlock
+	}
+
+	return description
+}
@@ -0,0 +1,93 @@
+package swag
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlocks(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/blocks"
+	p := New()
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
+
+func TestJoinBlockLines(t *testing.T) {
+	t.Parallel()
+
+	joined, starts := joinBlockLines([]string{
+		"// ListUsers lists the users.",
+		"//	@Description |",
+		"//	  Lists the users.",
+		"//",
+		"//	  - by name",
+		"//swag:nolint",
+		"//	@Param name query string false |",
+		"//	  The name.",
+		"//	@Router /users [get]",
+	})
+	assert.Equal(t, []string{
+		"// ListUsers lists the users.",
+		"//	@Description |\n//	  Lists the users.\n//\n//	  - by name",
+		"//swag:nolint",
+		"//	@Param name query string false |\n//	  The name.",
+		"//	@Router /users [get]",
+	}, joined)
+	assert.Equal(t, []int{0, 1, 5, 6, 8}, starts)
+}
+
+func TestCutCommentBlock(t *testing.T) {
+	t.Parallel()
+
+	line, block := cutCommentBlock("// @Description |\n//\n//	  Lists the users:\n//\n//	  - by name\n//	      code \n//")
+	assert.Equal(t, "// @Description |", line)
+	assert.Equal(t, "Lists the users:\n\n- by name\n    code", block)
+
+	line, block = cutCommentBlock("// @Summary List")
+	assert.Equal(t, "// @Summary List", line)
+	assert.Empty(t, block)
+}
+
+func TestCutBlockMarker(t *testing.T) {
+	t.Parallel()
+
+	line, ok := cutBlockMarker("200 {object} User |  ")
+	assert.True(t, ok)
+	assert.Equal(t, "200 {object} User", line)
+
+	_, ok = cutBlockMarker("200 {object} User \"a | b\"")
+	assert.False(t, ok)
+
+	_, ok = cutBlockMarker("|")
+	assert.False(t, ok)
+
+	assert.Equal(t, `id path int true "|"`, quoteBlockMarker("id path int true |"))
+}
+
+func TestOperation_ParseCommentBlock(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(New())
+	require.NoError(t, operation.ParseComment("// @Description A user.", nil))
+	require.NoError(t, operation.ParseComment("// @Description |\n//   **Markdown**\n//\n//   - kept", nil))
+	require.NoError(t, operation.ParseComment("// @Param id path int true |\n//   The user id.", nil))
+	require.NoError(t, operation.ParseComment("// @Success 200 {string} string |\n//   The user.", nil))
+	require.NoError(t, operation.ParseComment("// @Failure 404 |", nil))
+
+	assert.Equal(t, "A user.\n**Markdown**\n\n- kept", operation.Description)
+	assert.Equal(t, "The user id.", operation.Parameters[0].Description)
+	assert.Equal(t, "The user.", operation.Responses.StatusCodeResponses[200].Description)
+	assert.Equal(t, "", operation.Responses.StatusCodeResponses[404].Description)
+	assert.Empty(t, operation.commentBlock)
+}
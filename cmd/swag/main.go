@@ -1,17 +1,25 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/go-openapi/spec"
 	"github.com/urfave/cli/v2"
+	"sigs.k8s.io/yaml"
 
 	"github.com/swaggo/swag"
 	"github.com/swaggo/swag/format"
 	"github.com/swaggo/swag/gen"
+	"github.com/swaggo/swag/lint"
+	"github.com/swaggo/swag/lsp"
+	"github.com/swaggo/swag/stats"
 )
 
 const (
@@ -25,12 +33,14 @@ const (
 	parseVendorFlag          = "parseVendor"
 	parseDependencyFlag      = "parseDependency"
 	useStructNameFlag        = "useStructName"
+	definitionNameFlag       = "definitionName"
 	parseDependencyLevelFlag = "parseDependencyLevel"
 	markdownFilesFlag        = "markdownFiles"
 	codeExampleFilesFlag     = "codeExampleFiles"
 	parseInternalFlag        = "parseInternal"
 	generatedTimeFlag        = "generatedTime"
 	requiredByDefaultFlag    = "requiredByDefault"
+	goDocDescriptionsFlag    = "goDocDescriptions"
 	parseDepthFlag           = "parseDepth"
 	instanceNameFlag         = "instanceName"
 	overridesFileFlag        = "overridesFile"
@@ -45,20 +55,28 @@ const (
 	stateFlag                = "state"
 	parseFuncBodyFlag        = "parseFuncBody"
 	parseGoPackagesFlag      = "parseGoPackages"
+	documentFlag             = "document"
+	keepUnusedFlag           = "keepUnusedDefinitions"
+	prefixFlag               = "prefix"
+	strictFlag               = "strict"
+	titleFlag                = "title"
+	checkFlag                = "check"
+	diffFlag                 = "diff"
+	listFlag                 = "list"
+	canonicalAttributesFlag  = "canonicalAttributes"
+	sortAttributesFlag       = "sortAttributes"
+	mimeTypesFlag            = "mimeTypes"
+	normalizeRequiredFlag    = "normalizeRequired"
+	quoteDescriptionsFlag    = "quoteDescriptions"
+	rulesFlag                = "rules"
+	disableFlag              = "disable"
+	diagnosticsFormatFlag    = "diagnosticsFormat"
+	thresholdFlag            = "threshold"
 )
 
-var initFlags = []cli.Flag{
-	&cli.BoolFlag{
-		Name:    quietFlag,
-		Aliases: []string{"q"},
-		Usage:   "Make the logger quiet.",
-	},
-	&cli.StringFlag{
-		Name:    generalInfoFlag,
-		Aliases: []string{"g"},
-		Value:   "main.go",
-		Usage:   "Go file path in which 'swagger general API Info' is written",
-	},
+// projectFlagList are the flags locating and parsing the project, shared by the commands
+// parsing it like init.
+var projectFlagList = []cli.Flag{
 	&cli.StringFlag{
 		Name:    searchDirFlag,
 		Aliases: []string{"d"},
@@ -70,26 +88,15 @@ var initFlags = []cli.Flag{
 		Usage: "Exclude directories and files when searching, comma separated",
 	},
 	&cli.StringFlag{
-		Name:    propertyStrategyFlag,
-		Aliases: []string{"p"},
-		Value:   swag.CamelCase,
-		Usage:   "Property Naming Strategy like " + swag.SnakeCase + "," + swag.CamelCase + "," + swag.PascalCase,
-	},
-	&cli.StringFlag{
-		Name:    outputFlag,
-		Aliases: []string{"o"},
-		Value:   "./docs",
-		Usage:   "Output directory for all the generated files(swagger.json, swagger.yaml and docs.go)",
-	},
-	&cli.StringFlag{
-		Name:    outputTypesFlag,
-		Aliases: []string{"ot"},
-		Value:   "go,json,yaml",
-		Usage:   "Output types of generated files (docs.go, swagger.json, swagger.yaml) like go,json,yaml",
+		Name:    generalInfoFlag,
+		Aliases: []string{"g"},
+		Value:   "main.go",
+		Usage:   "Go file path in which 'swagger general API Info' is written",
 	},
-	&cli.BoolFlag{
-		Name:  parseVendorFlag,
-		Usage: "Parse go files in 'vendor' folder, disabled by default",
+	&cli.IntFlag{
+		Name:  parseDepthFlag,
+		Value: 100,
+		Usage: "Dependency parse depth",
 	},
 	&cli.IntFlag{
 		Name:    parseDependencyLevelFlag,
@@ -102,9 +109,12 @@ var initFlags = []cli.Flag{
 		Usage:   "Parse go files inside dependency folder, disabled by default",
 	},
 	&cli.BoolFlag{
-		Name:    useStructNameFlag,
-		Aliases: []string{"st"},
-		Usage:   "Dont use those ugly full-path names when using dependency flag",
+		Name:  parseInternalFlag,
+		Usage: "Parse go files in internal packages, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  parseVendorFlag,
+		Usage: "Parse go files in 'vendor' folder, disabled by default",
 	},
 	&cli.StringFlag{
 		Name:    markdownFilesFlag,
@@ -118,49 +128,114 @@ var initFlags = []cli.Flag{
 		Value:   "",
 		Usage:   "Parse folder containing code example files to use for the x-codeSamples extension, disabled by default",
 	},
+	&cli.StringFlag{
+		Name:  overridesFileFlag,
+		Value: gen.DefaultOverridesFile,
+		Usage: "File to read global type overrides from.",
+	},
 	&cli.BoolFlag{
-		Name:  parseInternalFlag,
-		Usage: "Parse go files in internal packages, disabled by default",
+		Name:  parseGoListFlag,
+		Value: true,
+		Usage: "Parse dependency via 'go list'",
+	},
+	&cli.StringFlag{
+		Name:    tagsFlag,
+		Aliases: []string{"t"},
+		Value:   "",
+		Usage:   "A comma-separated list of tags to filter the APIs for which the documentation is generated.Special case if the tag is prefixed with the '!' character then the APIs with that tag will be excluded",
+	},
+}
+
+// projectFlags returns the project flags followed by the flags of a command.
+func projectFlags(flags ...cli.Flag) []cli.Flag {
+	return append(append([]cli.Flag{}, projectFlagList...), flags...)
+}
+
+// project returns the project located and configured by the project flags.
+func project(ctx *cli.Context) gen.Project {
+	return gen.Project{
+		SearchDir:           ctx.String(searchDirFlag),
+		Excludes:            ctx.String(excludeFlag),
+		MainAPIFile:         ctx.String(generalInfoFlag),
+		MarkdownFilesDir:    ctx.String(markdownFilesFlag),
+		CodeExampleFilesDir: ctx.String(codeExampleFilesFlag),
+		ParseDepth:          ctx.Int(parseDepthFlag),
+		ParseVendor:         ctx.Bool(parseVendorFlag),
+		ParseDependency:     parseDependencyLevel(ctx),
+		ParseInternal:       ctx.Bool(parseInternalFlag),
+		OverridesFile:       ctx.String(overridesFileFlag),
+		ParseGoList:         ctx.Bool(parseGoListFlag),
+		Tags:                ctx.String(tagsFlag),
+	}
+}
+
+// parseDependencyLevel returns the level of the parseDependencyLevel flag, or the one of
+// the parseDependency flag when it is not given.
+func parseDependencyLevel(ctx *cli.Context) int {
+	pdv := ctx.Int(parseDependencyLevelFlag)
+	if pdv == 0 && ctx.Bool(parseDependencyFlag) {
+		pdv = 1
+	}
+
+	return pdv
+}
+
+var initFlags = projectFlags(
+	&cli.BoolFlag{
+		Name:    quietFlag,
+		Aliases: []string{"q"},
+		Usage:   "Make the logger quiet.",
+	},
+	&cli.StringFlag{
+		Name:    propertyStrategyFlag,
+		Aliases: []string{"p"},
+		Value:   swag.CamelCase,
+		Usage:   "Property Naming Strategy like " + swag.SnakeCase + "," + swag.CamelCase + "," + swag.PascalCase,
+	},
+	&cli.StringFlag{
+		Name:    outputFlag,
+		Aliases: []string{"o"},
+		Value:   "./docs",
+		Usage:   "Output directory for all the generated files(swagger.json, swagger.yaml and docs.go)",
+	},
+	&cli.StringFlag{
+		Name:    outputTypesFlag,
+		Aliases: []string{"ot"},
+		Value:   "go,json,yaml",
+		Usage:   "Output types of generated files (docs.go, swagger.json, swagger.yaml, swagger.html, swagger.md, client/client.go, swagger.ts) like go,json,yaml,html,md,client,ts",
+	},
+	&cli.BoolFlag{
+		Name:    useStructNameFlag,
+		Aliases: []string{"st"},
+		Usage:   "Dont use those ugly full-path names when using dependency flag",
+	},
+	&cli.StringFlag{
+		Name:  definitionNameFlag,
+		Value: "",
+		Usage: "Go template naming the definitions with their {{.Package}}, {{.Type}}, {{.TypeArgs}} and {{.ImportPath}}, like {{.Type}}{{.TypeArgs}}, package.Type by default",
 	},
 	&cli.BoolFlag{
 		Name:  generatedTimeFlag,
 		Usage: "Generate timestamp at the top of docs.go, disabled by default",
 	},
-	&cli.IntFlag{
-		Name:  parseDepthFlag,
-		Value: 100,
-		Usage: "Dependency parse depth",
-	},
 	&cli.BoolFlag{
 		Name:  requiredByDefaultFlag,
 		Usage: "Set validation required for all fields by default",
 	},
+	&cli.BoolFlag{
+		Name:  goDocDescriptionsFlag,
+		Usage: "Use the Go doc comments of the operations as their summary and description when they have none, disabled by default",
+	},
 	&cli.StringFlag{
 		Name:  instanceNameFlag,
 		Value: "",
 		Usage: "This parameter can be used to name different swagger document instances. It is optional.",
 	},
-	&cli.StringFlag{
-		Name:  overridesFileFlag,
-		Value: gen.DefaultOverridesFile,
-		Usage: "File to read global type overrides from.",
-	},
-	&cli.BoolFlag{
-		Name:  parseGoListFlag,
-		Value: true,
-		Usage: "Parse dependency via 'go list'",
-	},
 	&cli.StringFlag{
 		Name:  parseExtensionFlag,
 		Value: "",
 		Usage: "Parse only those operations that match given extension",
 	},
-	&cli.StringFlag{
-		Name:    tagsFlag,
-		Aliases: []string{"t"},
-		Value:   "",
-		Usage:   "A comma-separated list of tags to filter the APIs for which the documentation is generated.Special case if the tag is prefixed with the '!' character then the APIs with that tag will be excluded",
-	},
 	&cli.StringFlag{
 		Name:    templateDelimsFlag,
 		Aliases: []string{"td"},
@@ -196,7 +271,24 @@ var initFlags = []cli.Flag{
 		Name:  parseGoPackagesFlag,
 		Usage: "Parse Go sources by golang.org/x/tools/go/packages, disabled by default",
 	},
-}
+	&cli.BoolFlag{
+		Name:  keepUnusedFlag,
+		Usage: "Keep the definitions which no operation references, disabled by default",
+	},
+	&cli.BoolFlag{
+		Name:  strictFlag,
+		Usage: "Fail instead of warning on cases which are most likely user errors, like unknown attributes or routes declared multiple times",
+	},
+	&cli.StringFlag{
+		Name:    diagnosticsFormatFlag,
+		Aliases: []string{"diagnostics-format"},
+		Usage:   "Write the errors and warnings to stdout as text, json, sarif or github workflow commands",
+	},
+	&cli.StringSliceFlag{
+		Name:  documentFlag,
+		Usage: "Generate a document from the selected operations instead of one for all, like name:tags=a|!b;packages=github.com/x/api;paths=/v1, can be repeated",
+	},
+)
 
 func initAction(ctx *cli.Context) error {
 	strategy := ctx.String(propertyStrategyFlag)
@@ -244,43 +336,301 @@ func initAction(ctx *cli.Context) error {
 		)
 	}
 
-	var pdv = ctx.Int(parseDependencyLevelFlag)
-	if pdv == 0 {
-		if ctx.Bool(parseDependencyFlag) {
-			pdv = 1
-		}
+	documents, err := parseDocuments(ctx.StringSlice(documentFlag))
+	if err != nil {
+		return err
 	}
+
 	return gen.New().Build(&gen.Config{
-		SearchDir:           ctx.String(searchDirFlag),
-		Excludes:            ctx.String(excludeFlag),
-		ParseExtension:      ctx.String(parseExtensionFlag),
-		MainAPIFile:         ctx.String(generalInfoFlag),
-		PropNamingStrategy:  strategy,
-		OutputDir:           ctx.String(outputFlag),
-		OutputTypes:         outputTypes,
-		ParseVendor:         ctx.Bool(parseVendorFlag),
-		ParseDependency:     pdv,
-		MarkdownFilesDir:    ctx.String(markdownFilesFlag),
-		ParseInternal:       ctx.Bool(parseInternalFlag),
-		UseStructNames:      ctx.Bool(useStructNameFlag),
-		GeneratedTime:       ctx.Bool(generatedTimeFlag),
-		RequiredByDefault:   ctx.Bool(requiredByDefaultFlag),
-		CodeExampleFilesDir: ctx.String(codeExampleFilesFlag),
-		ParseDepth:          ctx.Int(parseDepthFlag),
-		InstanceName:        ctx.String(instanceNameFlag),
-		OverridesFile:       ctx.String(overridesFileFlag),
-		ParseGoList:         ctx.Bool(parseGoListFlag),
-		Tags:                ctx.String(tagsFlag),
-		LeftTemplateDelim:   leftDelim,
-		RightTemplateDelim:  rightDelim,
-		PackageName:         ctx.String(packageName),
-		Debugger:            logger,
-		CollectionFormat:    collectionFormat,
-		PackagePrefix:       ctx.String(packagePrefixFlag),
-		State:               ctx.String(stateFlag),
-		ParseFuncBody:       ctx.Bool(parseFuncBodyFlag),
-		ParseGoPackages:     ctx.Bool(parseGoPackagesFlag),
+		SearchDir:             ctx.String(searchDirFlag),
+		Excludes:              ctx.String(excludeFlag),
+		ParseExtension:        ctx.String(parseExtensionFlag),
+		MainAPIFile:           ctx.String(generalInfoFlag),
+		PropNamingStrategy:    strategy,
+		OutputDir:             ctx.String(outputFlag),
+		OutputTypes:           outputTypes,
+		ParseVendor:           ctx.Bool(parseVendorFlag),
+		ParseDependency:       parseDependencyLevel(ctx),
+		MarkdownFilesDir:      ctx.String(markdownFilesFlag),
+		ParseInternal:         ctx.Bool(parseInternalFlag),
+		UseStructNames:        ctx.Bool(useStructNameFlag),
+		DefinitionName:        ctx.String(definitionNameFlag),
+		GeneratedTime:         ctx.Bool(generatedTimeFlag),
+		RequiredByDefault:     ctx.Bool(requiredByDefaultFlag),
+		GoDocDescriptions:     ctx.Bool(goDocDescriptionsFlag),
+		CodeExampleFilesDir:   ctx.String(codeExampleFilesFlag),
+		ParseDepth:            ctx.Int(parseDepthFlag),
+		InstanceName:          ctx.String(instanceNameFlag),
+		OverridesFile:         ctx.String(overridesFileFlag),
+		ParseGoList:           ctx.Bool(parseGoListFlag),
+		Tags:                  ctx.String(tagsFlag),
+		LeftTemplateDelim:     leftDelim,
+		RightTemplateDelim:    rightDelim,
+		PackageName:           ctx.String(packageName),
+		Strict:                ctx.Bool(strictFlag),
+		DiagnosticsFormat:     ctx.String(diagnosticsFormatFlag),
+		Debugger:              logger,
+		CollectionFormat:      collectionFormat,
+		PackagePrefix:         ctx.String(packagePrefixFlag),
+		State:                 ctx.String(stateFlag),
+		ParseFuncBody:         ctx.Bool(parseFuncBodyFlag),
+		ParseGoPackages:       ctx.Bool(parseGoPackagesFlag),
+		KeepUnusedDefinitions: ctx.Bool(keepUnusedFlag),
+		Documents:             documents,
+	})
+}
+
+// parseDocuments parses documents like name:tags=a|!b;packages=p;paths=/v1.
+func parseDocuments(values []string) ([]gen.Document, error) {
+	var documents []gen.Document
+
+	for _, value := range values {
+		name, selectors, _ := strings.Cut(value, ":")
+
+		document := gen.Document{InstanceName: strings.TrimSpace(name)}
+
+		for _, selector := range strings.Split(selectors, ";") {
+			if strings.TrimSpace(selector) == "" {
+				continue
+			}
+
+			kind, list, ok := strings.Cut(selector, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid document %q, expected name:tags=a|b;packages=a|b;paths=a|b", value)
+			}
+
+			items := strings.Split(list, "|")
+
+			switch strings.TrimSpace(kind) {
+			case "tags":
+				document.Tags = append(document.Tags, items...)
+			case "packages":
+				document.Packages = append(document.Packages, items...)
+			case "paths":
+				document.PathPrefixes = append(document.PathPrefixes, items...)
+			default:
+				return nil, fmt.Errorf("invalid document %q, unknown selector %q", value, kind)
+			}
+		}
+
+		documents = append(documents, document)
+	}
+
+	return documents, nil
+}
+
+func mergeAction(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("no document to merge, expected arguments like name=path/to/swagger.json")
+	}
+
+	prefixes := make(map[string]string)
+
+	for _, prefix := range ctx.StringSlice(prefixFlag) {
+		name, value, ok := strings.Cut(prefix, "=")
+		if !ok {
+			return fmt.Errorf("invalid prefix %q, expected name=/prefix", prefix)
+		}
+
+		prefixes[name] = value
+	}
+
+	sources := make([]swag.MergeSource, 0, ctx.NArg())
+
+	for _, arg := range ctx.Args().Slice() {
+		name, filename, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid document %q, expected name=path/to/swagger.json", arg)
+		}
+
+		b, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+
+		if b, err = yaml.YAMLToJSON(b); err != nil {
+			return fmt.Errorf("cannot parse %s: %w", filename, err)
+		}
+
+		var swagger spec.Swagger
+		if err = json.Unmarshal(b, &swagger); err != nil {
+			return fmt.Errorf("cannot parse %s: %w", filename, err)
+		}
+
+		sources = append(sources, swag.MergeSource{Name: name, Prefix: prefixes[name], Swagger: &swagger})
+	}
+
+	var info *spec.Info
+	if ctx.IsSet(titleFlag) {
+		info = &spec.Info{InfoProps: spec.InfoProps{Title: ctx.String(titleFlag)}}
+		if sources[0].Swagger.Info != nil {
+			info.Version = sources[0].Swagger.Info.Version
+		}
+	}
+
+	result, err := swag.Merge(info, sources...)
+	if err != nil {
+		return err
+	}
+
+	for _, conflict := range result.Conflicts {
+		log.Println(conflict)
+	}
+
+	if ctx.Bool(strictFlag) && len(result.Conflicts) > 0 {
+		return fmt.Errorf("%d conflicts found", len(result.Conflicts))
+	}
+
+	output := ctx.String(outputFlag)
+
+	b, err := json.MarshalIndent(result.Swagger, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if ext := filepath.Ext(output); ext == ".yaml" || ext == ".yml" {
+		if b, err = yaml.JSONToYAML(b); err != nil {
+			return err
+		}
+	}
+
+	if output == "" || output == "-" {
+		_, err = os.Stdout.Write(b)
+
+		return err
+	}
+
+	return os.WriteFile(output, b, 0o644)
+}
+
+func lintAction(ctx *cli.Context) error {
+	issues, err := lint.New().Lint(&lint.Config{
+		Project:       project(ctx),
+		Rules:         splitNames(ctx.String(rulesFlag)),
+		DisabledRules: splitNames(ctx.String(disableFlag)),
 	})
+	if err != nil {
+		return err
+	}
+
+	if format := ctx.String(diagnosticsFormatFlag); format != "" {
+		diagnostics := make(swag.Diagnostics, 0, len(issues))
+		for _, issue := range issues {
+			diagnostics = append(diagnostics, issue.Diagnostic())
+		}
+
+		err = swag.WriteDiagnostics(os.Stdout, format, diagnostics)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("%d issues found", len(issues))
+	}
+
+	return nil
+}
+
+func lspAction(ctx *cli.Context) error {
+	config := &lsp.Config{Project: project(ctx)}
+
+	// the root of the workspace is parsed unless a directory is given
+	if !ctx.IsSet(searchDirFlag) {
+		config.SearchDir = ""
+	}
+
+	return lsp.New().Run(config, os.Stdin, os.Stdout)
+}
+
+func statsAction(ctx *cli.Context) error {
+	report, err := stats.New().Report(&stats.Config{Project: project(ctx)})
+	if err != nil {
+		return err
+	}
+
+	if undocumented := report.Undocumented(); len(undocumented) > 0 {
+		fmt.Println("Undocumented routes:")
+
+		for _, route := range undocumented {
+			fmt.Printf("  %s\n", route)
+		}
+	}
+
+	if len(report.Unregistered) > 0 {
+		fmt.Println("Unregistered operations:")
+
+		for _, op := range report.Unregistered {
+			fmt.Printf("  %s\n", op)
+		}
+	}
+
+	fmt.Println("Coverage:")
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, coverage := range report.Packages() {
+		_, _ = fmt.Fprintf(w, "  %s\t%d/%d\t%.1f%%\n", coverage.Package, coverage.Documented, coverage.Routes, coverage.Percent())
+	}
+
+	_, _ = fmt.Fprintf(w, "  total\t%d/%d\t%.1f%%\n", len(report.Routes)-len(report.Undocumented()), len(report.Routes), report.Coverage())
+
+	err = w.Flush()
+	if err != nil {
+		return err
+	}
+
+	if threshold := ctx.Float64(thresholdFlag); report.Coverage() < threshold {
+		return fmt.Errorf("coverage %.1f%% is below the threshold %.1f%%", report.Coverage(), threshold)
+	}
+
+	return nil
+}
+
+// splitNames returns the names of a comma separated list, none if it is empty.
+func splitNames(list string) []string {
+	var names []string
+
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func lintRulesUsage() string {
+	names := make([]string, 0, len(lint.Rules))
+	for _, rule := range lint.Rules {
+		names = append(names, rule.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func formatterOptions(c *cli.Context) ([]func(*swag.Formatter), error) {
+	options := []func(*swag.Formatter){
+		swag.SetCanonicalAttributes(c.Bool(canonicalAttributesFlag)),
+		swag.SetSortAttributes(c.Bool(sortAttributesFlag)),
+		swag.SetNormalizeRequired(c.Bool(normalizeRequiredFlag)),
+		swag.SetQuoteDescriptions(c.Bool(quoteDescriptionsFlag)),
+	}
+
+	switch style := c.String(mimeTypesFlag); style {
+	case "", "keep":
+	case "expand":
+		options = append(options, swag.SetMimeTypeStyle(swag.ExpandMimeTypes))
+	case "collapse":
+		options = append(options, swag.SetMimeTypeStyle(swag.CollapseMimeTypes))
+	default:
+		return nil, fmt.Errorf("not supported %s mime types style, expected keep, expand or collapse", style)
+	}
+
+	return options, nil
 }
 
 func main() {
@@ -300,19 +650,26 @@ func main() {
 			Aliases: []string{"f"},
 			Usage:   "format swag comments",
 			Action: func(c *cli.Context) error {
+				options, err := formatterOptions(c)
+				if err != nil {
+					return err
+				}
 
 				if c.Bool(pipeFlag) {
-					return format.New().Run(os.Stdin, os.Stdout)
+					return format.New(options...).Run(os.Stdin, os.Stdout)
 				}
 
 				searchDir := c.String(searchDirFlag)
 				excludeDir := c.String(excludeFlag)
 				mainFile := c.String(generalInfoFlag)
 
-				return format.New().Build(&format.Config{
+				return format.New(options...).Build(&format.Config{
 					SearchDir: searchDir,
 					Excludes:  excludeDir,
 					MainFile:  mainFile,
+					Check:     c.Bool(checkFlag),
+					Diff:      c.Bool(diffFlag),
+					List:      c.Bool(listFlag),
 				})
 			},
 			Flags: []cli.Flag{
@@ -338,6 +695,104 @@ func main() {
 					Value:   false,
 					Usage:   "Read from stdin, write to stdout.",
 				},
+				&cli.BoolFlag{
+					Name:  checkFlag,
+					Usage: "List the files whose swag comments are not formatted without rewriting them, and fail if there are any",
+				},
+				&cli.BoolFlag{
+					Name:  diffFlag,
+					Usage: "Display the changes of the files whose swag comments are not formatted as unified diffs without rewriting them",
+				},
+				&cli.BoolFlag{
+					Name:    listFlag,
+					Aliases: []string{"l"},
+					Usage:   "List the files whose swag comments are not formatted without rewriting them",
+				},
+				&cli.BoolFlag{
+					Name:  canonicalAttributesFlag,
+					Usage: "Write the attributes of operations with their canonical casing, like @Success for @success",
+				},
+				&cli.BoolFlag{
+					Name:  sortAttributesFlag,
+					Usage: "Sort the attributes of operations: Summary, Description, Tags, Accept, Produce, Param, Success, Failure, Router...",
+				},
+				&cli.StringFlag{
+					Name:  mimeTypesFlag,
+					Value: "keep",
+					Usage: "Write the media types of @Accept and @Produce as they are (keep), as media types (expand) or as aliases like json (collapse)",
+				},
+				&cli.BoolFlag{
+					Name:  normalizeRequiredFlag,
+					Usage: "Write the required field of @Param as true or false",
+				},
+				&cli.BoolFlag{
+					Name:  quoteDescriptionsFlag,
+					Usage: "Quote the descriptions of @Param, @Success, @Failure, @Response and @Header",
+				},
+			},
+		},
+		{
+			Name:    "lint",
+			Aliases: []string{"l"},
+			Usage:   "Report the operations and models whose swag comments break the lint rules",
+			Action:  lintAction,
+			Flags: projectFlags(
+				&cli.StringFlag{
+					Name:  rulesFlag,
+					Usage: "Rules to check, comma separated, all by default: " + lintRulesUsage(),
+				},
+				&cli.StringFlag{
+					Name:  disableFlag,
+					Usage: "Rules not to check, comma separated",
+				},
+				&cli.StringFlag{
+					Name:    diagnosticsFormatFlag,
+					Aliases: []string{"diagnostics-format"},
+					Usage:   "Write the issues as text, json, sarif or github workflow commands instead of one per line",
+				},
+			),
+		},
+		{
+			Name:   "lsp",
+			Usage:  "Run a language server for swag comments over stdin and stdout, parsing the root of the workspace unless --dir is given",
+			Action: lspAction,
+			Flags:  projectFlags(),
+		},
+		{
+			Name:   "stats",
+			Usage:  "Report the registered routes without swag comments, the documented operations registered nowhere and the coverage of the packages",
+			Action: statsAction,
+			Flags: projectFlags(
+				&cli.Float64Flag{
+					Name:  thresholdFlag,
+					Usage: "Fail if the percentage of the documented routes is below the threshold",
+				},
+			),
+		},
+		{
+			Name:      "merge",
+			Aliases:   []string{"m"},
+			Usage:     "Merge several swagger documents into one",
+			ArgsUsage: "name=swagger.json [name=swagger.yaml ...]",
+			Action:    mergeAction,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    outputFlag,
+					Aliases: []string{"o"},
+					Usage:   "Output file of the merged document, as JSON or YAML depending on its extension, stdout if empty",
+				},
+				&cli.StringSliceFlag{
+					Name:  prefixFlag,
+					Usage: "Path prefix of a document, like name=/prefix, can be repeated",
+				},
+				&cli.StringFlag{
+					Name:  titleFlag,
+					Usage: "Title of the merged document, the info of the first document is used if empty",
+				},
+				&cli.BoolFlag{
+					Name:  strictFlag,
+					Usage: "Fail on any conflict, including the ones resolved by renaming",
+				},
 			},
 		},
 	}
@@ -0,0 +1,350 @@
+package swag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+const (
+	// SeverityError is the severity of the diagnostics failing the parsing.
+	SeverityError Severity = "error"
+
+	// SeverityWarning is the severity of the diagnostics which are most likely user errors.
+	SeverityWarning Severity = "warning"
+)
+
+// Codes of the diagnostics reported by the parser.
+const (
+	// InvalidCommentCode is the code of the comments which can't be parsed.
+	InvalidCommentCode = "invalid-comment"
+
+	// UnknownAttributeCode is the code of the attributes the parser doesn't know.
+	UnknownAttributeCode = "unknown-attribute"
+
+	// DuplicateRouteCode is the code of the routes declared multiple times.
+	DuplicateRouteCode = "duplicate-route"
+)
+
+// Formats of the diagnostics written by WriteDiagnostics.
+const (
+	// TextDiagnostics writes a diagnostic per line, like the go compiler.
+	TextDiagnostics = "text"
+
+	// JSONDiagnostics writes the diagnostics as a JSON array.
+	JSONDiagnostics = "json"
+
+	// SARIFDiagnostics writes the diagnostics as a SARIF 2.1.0 log, for code scanning tools.
+	SARIFDiagnostics = "sarif"
+
+	// GitHubDiagnostics writes the diagnostics as GitHub Actions workflow commands, which
+	// annotate the files of pull requests.
+	GitHubDiagnostics = "github"
+)
+
+// Diagnostic is an error or a warning about a comment, at the position of the comment.
+type Diagnostic struct {
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+}
+
+// newDiagnostic returns the diagnostic of an error at the position, the error itself when
+// it is a diagnostic already.
+func newDiagnostic(err error, position token.Position, code string) Diagnostic {
+	var diagnostic Diagnostic
+	if errors.As(err, &diagnostic) {
+		return diagnostic
+	}
+
+	return Diagnostic{
+		File:     position.Filename,
+		Line:     position.Line,
+		Column:   position.Column,
+		Severity: SeverityError,
+		Code:     code,
+		Message:  err.Error(),
+	}
+}
+
+// Position returns the position of the diagnostic.
+func (diagnostic Diagnostic) Position() token.Position {
+	return token.Position{Filename: diagnostic.File, Line: diagnostic.Line, Column: diagnostic.Column}
+}
+
+// Error returns the message of the diagnostic prefixed with its position, if any.
+func (diagnostic Diagnostic) Error() string {
+	if diagnostic.File == "" {
+		return diagnostic.Message
+	}
+
+	return diagnostic.Position().String() + ": " + diagnostic.Message
+}
+
+// Diagnostics are the diagnostics of the parsing, an error holding all of them.
+type Diagnostics []Diagnostic
+
+// Error returns the diagnostics, one per line.
+func (diagnostics Diagnostics) Error() string {
+	lines := make([]string, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		lines = append(lines, diagnostic.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Errors returns the diagnostics whose severity is error.
+func (diagnostics Diagnostics) Errors() Diagnostics {
+	var errs Diagnostics
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			errs = append(errs, diagnostic)
+		}
+	}
+
+	return errs
+}
+
+// collect adds the diagnostics of err, returning err when it holds none.
+func (diagnostics *Diagnostics) collect(err error) error {
+	var (
+		diagnostic Diagnostic
+		all        Diagnostics
+	)
+
+	switch {
+	case err == nil:
+	case errors.As(err, &all):
+		*diagnostics = append(*diagnostics, all...)
+	case errors.As(err, &diagnostic):
+		*diagnostics = append(*diagnostics, diagnostic)
+	default:
+		return err
+	}
+
+	return nil
+}
+
+// Diagnostics returns the errors and warnings reported while parsing.
+func (parser *Parser) Diagnostics() Diagnostics {
+	return parser.diagnostics
+}
+
+// CheckOperations parses the operations of a file without adding them to the document,
+// like a file being edited, and returns the diagnostics of their comments.
+func (parser *Parser) CheckOperations(fileSet *token.FileSet, astFile *ast.File) Diagnostics {
+	reported := parser.diagnostics
+	parser.diagnostics = nil
+
+	defer func() {
+		parser.diagnostics = reported
+	}()
+
+	var diagnostics Diagnostics
+
+	for _, decl := range astFile.Decls {
+		funcDoc, ok := getFuncDoc(decl)
+		if !ok || funcDoc == nil || len(funcDoc.List) == 0 {
+			continue
+		}
+
+		err := parser.checkOperation(fileSet, astFile, funcDoc.List)
+		if err != nil {
+			diagnostics = append(diagnostics, newDiagnostic(err, fileSet.Position(funcDoc.Pos()), InvalidCommentCode))
+		}
+	}
+
+	diagnostics = append(parser.diagnostics, diagnostics...)
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+
+	return diagnostics
+}
+
+func (parser *Parser) checkOperation(fileSet *token.FileSet, astFile *ast.File, comments []*ast.Comment) error {
+	operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
+
+	comments = joinCommentBlocks(comments)
+
+	lines := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		lines = append(lines, comment.Text)
+	}
+
+	inTemplate := templateBlockLines(lines)
+
+	for i, comment := range comments {
+		if inTemplate[i] {
+			continue
+		}
+
+		operation.position = fileSet.Position(comment.Pos())
+
+		err := operation.ParseComment(comment.Text, astFile)
+		if err != nil {
+			return newDiagnostic(err, operation.position, InvalidCommentCode)
+		}
+	}
+
+	return operation.applyTemplates()
+}
+
+// warn reports a warning at the position, or returns it as an error in strict mode.
+func (parser *Parser) warn(position token.Position, code string, err error) error {
+	diagnostic := newDiagnostic(err, position, code)
+	if parser.Strict {
+		return diagnostic
+	}
+
+	diagnostic.Severity = SeverityWarning
+	parser.diagnostics = append(parser.diagnostics, diagnostic)
+	parser.debug.Printf("warning: %s\n", diagnostic)
+
+	return nil
+}
+
+// WriteDiagnostics writes the diagnostics in the format, text, json, sarif or github.
+func WriteDiagnostics(w io.Writer, format string, diagnostics Diagnostics) error {
+	switch format {
+	case "", TextDiagnostics:
+		for _, diagnostic := range diagnostics {
+			line := fmt.Sprintf("%s: %s (%s)", diagnostic.Severity, diagnostic.Message, diagnostic.Code)
+			if diagnostic.File != "" {
+				line = diagnostic.Position().String() + ": " + line
+			}
+
+			_, err := fmt.Fprintln(w, line)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case JSONDiagnostics:
+		if diagnostics == nil {
+			diagnostics = Diagnostics{}
+		}
+
+		return writeJSON(w, diagnostics)
+	case SARIFDiagnostics:
+		return writeJSON(w, sarifLog(diagnostics))
+	case GitHubDiagnostics:
+		for _, diagnostic := range diagnostics {
+			_, err := fmt.Fprintln(w, githubCommand(diagnostic))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("not supported %s diagnostics format, expected text, json, sarif or github", format)
+	}
+}
+
+func writeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(value)
+}
+
+// sarifLog returns the SARIF 2.1.0 log of the diagnostics, reported by a swag run.
+func sarifLog(diagnostics Diagnostics) map[string]any {
+	var (
+		rules   []map[string]any
+		results = make([]map[string]any, 0, len(diagnostics))
+		codes   = make(map[string]bool)
+	)
+
+	for _, diagnostic := range diagnostics {
+		if !codes[diagnostic.Code] {
+			codes[diagnostic.Code] = true
+			rules = append(rules, map[string]any{"id": diagnostic.Code})
+		}
+
+		result := map[string]any{
+			"ruleId":  diagnostic.Code,
+			"level":   string(diagnostic.Severity),
+			"message": map[string]any{"text": diagnostic.Message},
+		}
+
+		if diagnostic.File != "" {
+			location := map[string]any{
+				"artifactLocation": map[string]any{"uri": filepath.ToSlash(diagnostic.File)},
+			}
+
+			if diagnostic.Line > 0 {
+				region := map[string]any{"startLine": diagnostic.Line}
+				if diagnostic.Column > 0 {
+					region["startColumn"] = diagnostic.Column
+				}
+
+				location["region"] = region
+			}
+
+			result["locations"] = []map[string]any{{"physicalLocation": location}}
+		}
+
+		results = append(results, result)
+	}
+
+	return map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []map[string]any{{
+			"tool": map[string]any{
+				"driver": map[string]any{
+					"name":           "swag",
+					"version":        Version,
+					"informationUri": "https://github.com/swaggo/swag",
+					"rules":          rules,
+				},
+			},
+			"results": results,
+		}},
+	}
+}
+
+var (
+	githubMessageEscaper  = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	githubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// githubCommand returns the workflow command annotating the file of the diagnostic, like
+// ::error file=api.go,line=12,col=4,title=invalid-comment::message.
+func githubCommand(diagnostic Diagnostic) string {
+	var properties []string
+
+	if diagnostic.File != "" {
+		properties = append(properties, "file="+githubPropertyEscaper.Replace(filepath.ToSlash(diagnostic.File)))
+	}
+
+	if diagnostic.Line > 0 {
+		properties = append(properties, fmt.Sprintf("line=%d", diagnostic.Line))
+	}
+
+	if diagnostic.Column > 0 {
+		properties = append(properties, fmt.Sprintf("col=%d", diagnostic.Column))
+	}
+
+	properties = append(properties, "title="+githubPropertyEscaper.Replace(diagnostic.Code))
+
+	return fmt.Sprintf("::%s %s::%s", diagnostic.Severity, strings.Join(properties, ","), githubMessageEscaper.Replace(diagnostic.Message))
+}
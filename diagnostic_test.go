@@ -0,0 +1,167 @@
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	err := p.ParseAPI("testdata/diagnostics", mainAPIFile, defaultParseDepth)
+
+	expected := Diagnostics{
+		{
+			File:     "testdata/diagnostics/api/api.go",
+			Line:     13,
+			Column:   1,
+			Severity: SeverityWarning,
+			Code:     UnknownAttributeCode,
+			Message:  "unknown attribute @Sucess, did you mean @Success?",
+		},
+		{
+			File:     "testdata/diagnostics/api/api.go",
+			Line:     21,
+			Column:   1,
+			Severity: SeverityError,
+			Code:     InvalidCommentCode,
+			Message:  "cannot find type definition: api.Member",
+		},
+		{
+			File:     "testdata/diagnostics/api/api.go",
+			Line:     28,
+			Column:   1,
+			Severity: SeverityError,
+			Code:     InvalidCommentCode,
+			Message:  "invalid method: REMOVE",
+		},
+	}
+	assert.Equal(t, expected, p.Diagnostics())
+
+	// every error is returned, not only the first one
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	assert.Equal(t, expected[1:], diagnostics)
+	assert.EqualError(t, err, "testdata/diagnostics/api/api.go:21:1: cannot find type definition: api.Member\n"+
+		"testdata/diagnostics/api/api.go:28:1: invalid method: REMOVE")
+}
+
+func TestParseDiagnosticsStrict(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetStrict(true))
+	err := p.ParseAPI("testdata/diagnostics", mainAPIFile, defaultParseDepth)
+
+	var diagnostics Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	assert.Len(t, diagnostics, 3)
+	assert.Equal(t, SeverityError, diagnostics[0].Severity)
+	assert.Equal(t, UnknownAttributeCode, diagnostics[0].Code)
+}
+
+func TestDiagnostic_Error(t *testing.T) {
+	t.Parallel()
+
+	diagnostic := newDiagnostic(errors.New("invalid method: REMOVE"),
+		token.Position{Filename: "api/api.go", Line: 12, Column: 4}, InvalidCommentCode)
+	assert.Equal(t, "api/api.go:12:4: invalid method: REMOVE", diagnostic.Error())
+	assert.Equal(t, SeverityError, diagnostic.Severity)
+
+	// an error which is a diagnostic already keeps its position and code
+	assert.Equal(t, diagnostic, newDiagnostic(diagnostic, token.Position{}, UnknownAttributeCode))
+
+	assert.Equal(t, "invalid method: REMOVE", Diagnostic{Message: "invalid method: REMOVE"}.Error())
+	assert.Equal(t, "api/api.go: invalid method: REMOVE", Diagnostic{File: "api/api.go", Message: "invalid method: REMOVE"}.Error())
+}
+
+func TestWriteDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	diagnostics := Diagnostics{
+		{File: "api/api.go", Line: 13, Column: 1, Severity: SeverityWarning, Code: UnknownAttributeCode, Message: "unknown attribute @Sucess"},
+		{File: "api/api.go", Line: 21, Column: 1, Severity: SeverityError, Code: InvalidCommentCode, Message: "100% invalid,\nreally"},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteDiagnostics(&buf, TextDiagnostics, diagnostics))
+		assert.Equal(t, "api/api.go:13:1: warning: unknown attribute @Sucess (unknown-attribute)\n"+
+			"api/api.go:21:1: error: 100% invalid,\nreally (invalid-comment)\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteDiagnostics(&buf, JSONDiagnostics, diagnostics))
+
+		var decoded Diagnostics
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, diagnostics, decoded)
+
+		buf.Reset()
+		require.NoError(t, WriteDiagnostics(&buf, JSONDiagnostics, nil))
+		assert.Equal(t, "[]\n", buf.String())
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteDiagnostics(&buf, SARIFDiagnostics, diagnostics))
+
+		var log struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Tool struct {
+					Driver struct {
+						Name  string `json:"name"`
+						Rules []struct {
+							ID string `json:"id"`
+						} `json:"rules"`
+					} `json:"driver"`
+				} `json:"tool"`
+				Results []struct {
+					RuleID    string `json:"ruleId"`
+					Level     string `json:"level"`
+					Locations []struct {
+						PhysicalLocation struct {
+							ArtifactLocation struct {
+								URI string `json:"uri"`
+							} `json:"artifactLocation"`
+							Region struct {
+								StartLine   int `json:"startLine"`
+								StartColumn int `json:"startColumn"`
+							} `json:"region"`
+						} `json:"physicalLocation"`
+					} `json:"locations"`
+				} `json:"results"`
+			} `json:"runs"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+
+		assert.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		assert.Equal(t, "swag", log.Runs[0].Tool.Driver.Name)
+		assert.Len(t, log.Runs[0].Tool.Driver.Rules, 2)
+		require.Len(t, log.Runs[0].Results, 2)
+		assert.Equal(t, "unknown-attribute", log.Runs[0].Results[0].RuleID)
+		assert.Equal(t, "warning", log.Runs[0].Results[0].Level)
+		assert.Equal(t, "api/api.go", log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 13, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+		assert.Equal(t, "error", log.Runs[0].Results[1].Level)
+	})
+
+	t.Run("github", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteDiagnostics(&buf, GitHubDiagnostics, diagnostics))
+		assert.Equal(t, "::warning file=api/api.go,line=13,col=1,title=unknown-attribute::unknown attribute @Sucess\n"+
+			"::error file=api/api.go,line=21,col=1,title=invalid-comment::100%25 invalid,%0Areally\n", buf.String())
+	})
+
+	assert.EqualError(t, WriteDiagnostics(&bytes.Buffer{}, "xml", diagnostics),
+		"not supported xml diagnostics format, expected text, json, sarif or github")
+}
@@ -0,0 +1,387 @@
+package swag
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const definitionsRefPrefix = "#/definitions/"
+
+var routeMethods = []string{
+	http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+	http.MethodOptions, http.MethodHead, http.MethodPatch,
+}
+
+// PathItemOperation returns the operation of the path item for the HTTP method, nil if it
+// has none.
+func PathItemOperation(item *spec.PathItem, method string) *spec.Operation {
+	if op := refRouteMethodOp(item, method); op != nil {
+		return *op
+	}
+
+	return nil
+}
+
+// DocumentSelector selects the operations of a document split from the parsed API.
+// An operation is selected when it matches every non-empty selector, and it matches a
+// selector when it matches one of its values.
+type DocumentSelector struct {
+	// Tags of the operations, a tag prefixed with '!' excludes the operations having it,
+	// like the tags of SetTags.
+	Tags []string
+
+	// Packages are prefixes of the import path of the package declaring the operations.
+	Packages []string
+
+	// PathPrefixes are leading segments of the paths of the operations, like /api/v1.
+	PathPrefixes []string
+}
+
+// OperationPackage returns the import path of the package in which the given parsed
+// operation is declared.
+func (parser *Parser) OperationPackage(operation *spec.Operation) string {
+	return parser.operationPackages[operation]
+}
+
+// SelectDocument returns a document holding the parsed operations matched by selector,
+// the tags they may use and only the definitions they reach. The parsed document is left
+// unchanged, and shares its unselected parts with the returned one.
+func (parser *Parser) SelectDocument(selector DocumentSelector) *spec.Swagger {
+	tags := make(map[string]struct{}, len(selector.Tags))
+	for _, tag := range selector.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags[tag] = struct{}{}
+		}
+	}
+
+	document := *parser.swagger
+	document.Paths = &spec.Paths{
+		VendorExtensible: parser.swagger.Paths.VendorExtensible,
+		Paths:            make(map[string]spec.PathItem),
+	}
+
+	for name, pathItem := range parser.swagger.Paths.Paths {
+		if !matchPathPrefixes(selector.PathPrefixes, name) {
+			continue
+		}
+
+		selected, found := pathItem, false
+
+		for _, method := range routeMethods {
+			op := refRouteMethodOp(&selected, method)
+			if *op == nil {
+				continue
+			}
+
+			if matchTagSetAny(tags, (*op).Tags) && matchPackagePrefixes(selector.Packages, parser.OperationPackage(*op)) {
+				found = true
+			} else {
+				*op = nil
+			}
+		}
+
+		if found {
+			document.Paths.Paths[name] = selected
+		}
+	}
+
+	document.Tags = nil
+
+	for _, tag := range parser.swagger.Tags {
+		if matchTagSet(tags, tag.Name) {
+			document.Tags = append(document.Tags, tag)
+		}
+	}
+
+	document.Definitions = make(spec.Definitions, len(parser.swagger.Definitions))
+	for name, schema := range parser.swagger.Definitions {
+		document.Definitions[name] = schema
+	}
+
+	PruneDefinitions(&document)
+
+	return &document
+}
+
+// PruneDefinitions removes the definitions of swagger which cannot be reached from its
+// paths, parameters and responses, and returns their sorted names.
+func PruneDefinitions(swagger *spec.Swagger) []string {
+	reachable := make(map[string]struct{}, len(swagger.Definitions))
+
+	var visit func(schema *spec.Schema)
+	visit = func(schema *spec.Schema) {
+		walkSchema(schema, func(schema *spec.Schema) {
+			ref := schema.Ref.String()
+			if !strings.HasPrefix(ref, definitionsRefPrefix) {
+				return
+			}
+
+			name := ref[len(definitionsRefPrefix):]
+			if _, ok := reachable[name]; ok {
+				return
+			}
+
+			reachable[name] = struct{}{}
+
+			if definition, ok := swagger.Definitions[name]; ok {
+				visit(&definition)
+			}
+		})
+	}
+
+	visitResponse := func(response *spec.Response) {
+		visit(response.Schema)
+
+		for _, schema := range responseContentSchemas(response) {
+			visit(schema)
+		}
+	}
+
+	for _, parameter := range swagger.Parameters {
+		visit(parameter.Schema)
+	}
+
+	for _, response := range swagger.Responses {
+		visitResponse(&response)
+	}
+
+	var visitPathItem func(pathItem spec.PathItem)
+	visitPathItem = func(pathItem spec.PathItem) {
+		for _, parameter := range pathItem.Parameters {
+			visit(parameter.Schema)
+		}
+
+		for _, method := range routeMethods {
+			op := *refRouteMethodOp(&pathItem, method)
+			if op == nil {
+				continue
+			}
+
+			for _, parameter := range op.Parameters {
+				visit(parameter.Schema)
+			}
+
+			for _, callback := range webhookPathItems(op.Extensions) {
+				visitPathItem(callback)
+			}
+
+			if op.Responses == nil {
+				continue
+			}
+
+			if op.Responses.Default != nil {
+				visitResponse(op.Responses.Default)
+			}
+
+			for _, response := range op.Responses.StatusCodeResponses {
+				visitResponse(&response)
+			}
+		}
+	}
+
+	if swagger.Paths != nil {
+		for _, pathItem := range swagger.Paths.Paths {
+			visitPathItem(pathItem)
+		}
+	}
+
+	for _, webhook := range webhookPathItems(swagger.Extensions) {
+		visitPathItem(webhook)
+	}
+
+	var removed []string
+
+	for name := range swagger.Definitions {
+		if _, ok := reachable[name]; !ok {
+			removed = append(removed, name)
+			delete(swagger.Definitions, name)
+		}
+	}
+
+	sort.Strings(removed)
+
+	return removed
+}
+
+// walkSchema calls fn for schema and every schema nested in it, writing the nested
+// schemas back after fn returns so that fn may modify them.
+func walkSchema(schema *spec.Schema, fn func(*spec.Schema)) {
+	if schema == nil {
+		return
+	}
+
+	fn(schema)
+
+	if schema.Items != nil {
+		walkSchema(schema.Items.Schema, fn)
+
+		for i := range schema.Items.Schemas {
+			walkSchema(&schema.Items.Schemas[i], fn)
+		}
+	}
+
+	for _, schemas := range [][]spec.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for i := range schemas {
+			walkSchema(&schemas[i], fn)
+		}
+	}
+
+	for _, properties := range []spec.SchemaProperties{schema.Properties, schema.PatternProperties, spec.SchemaProperties(schema.Definitions)} {
+		for name, property := range properties {
+			walkSchema(&property, fn)
+			properties[name] = property
+		}
+	}
+
+	if schema.AdditionalProperties != nil {
+		walkSchema(schema.AdditionalProperties.Schema, fn)
+	}
+
+	if schema.AdditionalItems != nil {
+		walkSchema(schema.AdditionalItems.Schema, fn)
+	}
+
+	walkSchema(schema.Not, fn)
+}
+
+func matchPackagePrefixes(prefixes []string, packagePath string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(packagePath, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPathPrefixes reports whether the path starts with the segments of one of the
+// prefixes, so that /api/v1 matches /api/v1/users but not /api/v10.
+func matchPathPrefixes(prefixes []string, path string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+
+	for _, prefix := range prefixes {
+		prefix = "/" + strings.Trim(prefix, "/")
+		if prefix == "/" || path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DefinitionsReport lists the definitions dropped by PruneUnusedDefinitions and the
+// annotated models which are not part of the document.
+type DefinitionsReport struct {
+	// Dropped are the definitions which no operation references, sorted by name.
+	Dropped []DroppedDefinition
+
+	// Unused are the type definitions carrying swag annotations, like @name or
+	// @Description, which are not part of the document, sorted by full path.
+	Unused []*TypeSpecDef
+}
+
+// DroppedDefinition is a definition removed from the document.
+type DroppedDefinition struct {
+	Name string
+
+	// TypeSpec is the type definition behind the schema, nil if it has none.
+	TypeSpec *TypeSpecDef
+}
+
+// PruneUnusedDefinitions removes the definitions which cannot be reached from the paths,
+// parameters and responses of the parsed document, and reports them along with the
+// annotated models which are not part of it.
+func (parser *Parser) PruneUnusedDefinitions() *DefinitionsReport {
+	typeSpecs := parser.GetDefinitionTypeSpecs()
+	report := &DefinitionsReport{}
+
+	for _, name := range PruneDefinitions(parser.swagger) {
+		typeSpec := typeSpecs[name]
+		if typeSpec != nil {
+			delete(parser.outputSchemas, typeSpec)
+		}
+
+		report.Dropped = append(report.Dropped, DroppedDefinition{Name: name, TypeSpec: typeSpec})
+	}
+
+	for _, pkg := range parser.packages.packages {
+		for _, typeSpec := range pkg.TypeDefinitions {
+			if _, ok := parser.outputSchemas[typeSpec]; !ok && isAnnotatedTypeSpec(typeSpec) {
+				report.Unused = append(report.Unused, typeSpec)
+			}
+		}
+	}
+
+	sort.Slice(report.Unused, func(i, j int) bool {
+		return report.Unused[i].FullPath() < report.Unused[j].FullPath()
+	})
+
+	return report
+}
+
+// isAnnotatedTypeSpec reports whether the comments of the type definition hold a swag
+// annotation.
+func isAnnotatedTypeSpec(typeSpec *TypeSpecDef) bool {
+	if typeSpec.TypeSpec == nil {
+		return false
+	}
+
+	commentGroups := []*ast.CommentGroup{typeSpec.TypeSpec.Doc, typeSpec.TypeSpec.Comment}
+
+	if typeSpec.File != nil {
+		for _, decl := range typeSpec.File.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+
+			for _, astSpec := range genDecl.Specs {
+				if astSpec == typeSpec.TypeSpec {
+					commentGroups = append(commentGroups, genDecl.Doc)
+				}
+			}
+		}
+	}
+
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+
+		for _, comment := range commentGroup.List {
+			if strings.HasPrefix(strings.TrimSpace(strings.TrimLeft(comment.Text, "/")), "@") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// decodeExtension decodes an extension into value, whether it holds the value the parser
+// set or the one decoded with a document, and reports whether it could.
+func decodeExtension(extensions spec.Extensions, name string, value any) bool {
+	extension, ok := extensions[name]
+	if !ok {
+		return false
+	}
+
+	b, err := json.Marshal(extension)
+	if err != nil {
+		return false
+	}
+
+	return json.Unmarshal(b, value) == nil
+}
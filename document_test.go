@@ -0,0 +1,112 @@
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_SelectDocument(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, p.ParseAPI("testdata/split", mainAPIFile, defaultParseDepth))
+
+	tagNames := func(swagger *spec.Swagger) []string {
+		var names []string
+		for _, tag := range swagger.Tags {
+			names = append(names, tag.Name)
+		}
+
+		return names
+	}
+
+	public := p.SelectDocument(DocumentSelector{Tags: []string{"!internal", "!admin"}})
+	assert.Equal(t, []string{"/v1/users"}, sortedKeys(public.Paths.Paths))
+	assert.Equal(t, []string{"model.Error", "model.User", "model.UserPage"}, sortedKeys(public.Definitions))
+	assert.Equal(t, []string{"users"}, tagNames(public))
+
+	admin := p.SelectDocument(DocumentSelector{Packages: []string{"github.com/swaggo/swag/testdata/split/api/admin"}})
+	assert.Equal(t, []string{"/admin/audit", "/admin/users"}, sortedKeys(admin.Paths.Paths))
+	assert.Equal(t, []string{"model.AuditEntry", "model.Error", "model.User"}, sortedKeys(admin.Definitions))
+
+	users := p.SelectDocument(DocumentSelector{Tags: []string{"users"}, PathPrefixes: []string{"/admin/"}})
+	assert.Equal(t, []string{"/admin/users"}, sortedKeys(users.Paths.Paths))
+	assert.NotNil(t, users.Paths.Paths["/admin/users"].Delete)
+	assert.Equal(t, []string{"model.Error"}, sortedKeys(users.Definitions))
+	assert.Equal(t, []string{"users"}, tagNames(users))
+
+	assert.Empty(t, p.SelectDocument(DocumentSelector{PathPrefixes: []string{"/v"}}).Paths.Paths)
+
+	// the parsed document is left unchanged
+	assert.Len(t, p.swagger.Paths.Paths, 4)
+	assert.Len(t, p.swagger.Definitions, 4)
+	assert.Len(t, p.swagger.Tags, 3)
+	assert.Equal(t, "github.com/swaggo/swag/testdata/split/api/public", p.OperationPackage(p.swagger.Paths.Paths["/v1/health"].Get))
+}
+
+func TestPruneDefinitions(t *testing.T) {
+	t.Parallel()
+
+	swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/a": {PathItemProps: spec.PathItemProps{Post: &spec.Operation{OperationProps: spec.OperationProps{
+				Parameters: []spec.Parameter{*spec.BodyParam("body", spec.ArrayProperty(spec.RefSchema("#/definitions/A")))},
+			}}}},
+		}},
+		Responses: map[string]spec.Response{
+			"Error": *spec.NewResponse().WithSchema(spec.RefSchema("#/definitions/Error")),
+		},
+		Definitions: spec.Definitions{
+			"A":      *spec.MapProperty(spec.RefSchema("#/definitions/B")),
+			"B":      {SchemaProps: spec.SchemaProps{AllOf: []spec.Schema{*spec.RefSchema("#/definitions/A"), *spec.RefSchema("#/definitions/C")}}},
+			"C":      *spec.StringProperty(),
+			"Error":  *spec.StringProperty(),
+			"Unused": {SchemaProps: spec.SchemaProps{Properties: spec.SchemaProperties{"c": *spec.RefSchema("#/definitions/C"), "d": *spec.RefSchema("#/definitions/D")}}},
+			"D":      *spec.StringProperty(),
+		},
+	}}
+
+	assert.Equal(t, []string{"D", "Unused"}, PruneDefinitions(swagger))
+	assert.Equal(t, []string{"A", "B", "C", "Error"}, sortedKeys(swagger.Definitions))
+	assert.Empty(t, PruneDefinitions(swagger))
+}
+
+func TestParser_PruneUnusedDefinitions(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	p.HostState = "admin"
+	require.NoError(t, p.ParseAPI("testdata/state", mainAPIFile, defaultParseDepth))
+	require.Contains(t, p.swagger.Definitions, "web.RevValue")
+
+	report := p.PruneUnusedDefinitions()
+	require.Len(t, report.Dropped, 1)
+	assert.Equal(t, "web.RevValue", report.Dropped[0].Name)
+	assert.Equal(t, "github.com/swaggo/swag/testdata/state/web.RevValue", report.Dropped[0].TypeSpec.FullPath())
+	assert.NotContains(t, p.swagger.Definitions, "web.RevValue")
+	assert.NotContains(t, p.GetDefinitionTypeSpecs(), "web.RevValue")
+
+	p = New()
+	require.NoError(t, p.ParseAPI("testdata/split", mainAPIFile, defaultParseDepth))
+
+	report = p.PruneUnusedDefinitions()
+	assert.Empty(t, report.Dropped)
+	require.Len(t, report.Unused, 1)
+	assert.Equal(t, "github.com/swaggo/swag/testdata/split/model.AuditFilter", report.Unused[0].FullPath())
+}
+
+func TestPathItemOperation(t *testing.T) {
+	t.Parallel()
+
+	get, patch := spec.NewOperation("get"), spec.NewOperation("patch")
+	item := &spec.PathItem{PathItemProps: spec.PathItemProps{Get: get, Patch: patch}}
+
+	assert.Same(t, get, PathItemOperation(item, http.MethodGet))
+	assert.Same(t, patch, PathItemOperation(item, http.MethodPatch))
+	assert.Nil(t, PathItemOperation(item, http.MethodPost))
+	assert.Nil(t, PathItemOperation(item, "TRACE"))
+}
@@ -2,18 +2,25 @@ package format
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/swaggo/swag"
 	"golang.org/x/sync/errgroup"
 )
 
+// ErrNotFormatted is returned by Build in check mode when files are not formatted.
+var ErrNotFormatted = errors.New("swag comments are not formatted")
+
 // Format implements `fmt` command for formatting swag comments in Go source
 // files.
 type Format struct {
@@ -23,11 +30,11 @@ type Format struct {
 	exclude map[string]bool
 }
 
-// New creates a new Format instance
-func New() *Format {
+// New creates a new Format instance, formatting with the given formatter options
+func New(options ...func(*swag.Formatter)) *Format {
 	return &Format{
 		exclude:   map[string]bool{},
-		formatter: swag.NewFormatter(),
+		formatter: swag.NewFormatter(options...),
 	}
 }
 
@@ -41,6 +48,33 @@ type Config struct {
 
 	// MainFile (DEPRECATED)
 	MainFile string
+
+	// Check reports the files which are not formatted instead of rewriting them, and
+	// fails with ErrNotFormatted if there are any
+	Check bool
+
+	// Diff writes the changes of the files which are not formatted as unified diffs
+	// instead of rewriting them
+	Diff bool
+
+	// List writes the names of the files which are not formatted instead of rewriting
+	// them, like gofmt -l
+	List bool
+
+	// Output receives the reports of the check, diff and list modes, os.Stdout if nil
+	Output io.Writer
+}
+
+// rewrite reports whether the files which are not formatted are rewritten.
+func (c *Config) rewrite() bool {
+	return !c.Check && !c.Diff && !c.List
+}
+
+// unformattedFile is a file whose swag comments are not formatted.
+type unformattedFile struct {
+	path      string
+	original  []byte
+	formatted []byte
 }
 
 var defaultExcludes = []string{"docs", "vendor"}
@@ -61,7 +95,11 @@ func (f *Format) Build(config *Config) error {
 			f.exclude[filepath.Clean(fi)] = true
 		}
 	}
-	var eg errgroup.Group
+	var (
+		eg          errgroup.Group
+		mu          sync.Mutex
+		unformatted []unformattedFile
+	)
 	eg.SetLimit(runtime.GOMAXPROCS(0))
 	for _, searchDir := range searchDirs {
 		err := filepath.Walk(searchDir, func(path string, fileInfo fs.FileInfo, err error) error {
@@ -72,7 +110,14 @@ func (f *Format) Build(config *Config) error {
 				return nil
 			}
 			eg.Go(func() error {
-				return f.format(path)
+				file, err := f.format(path, config.rewrite())
+				if err != nil || file == nil {
+					return err
+				}
+				mu.Lock()
+				unformatted = append(unformatted, *file)
+				mu.Unlock()
+				return nil
 			})
 			return nil
 		})
@@ -83,9 +128,56 @@ func (f *Format) Build(config *Config) error {
 	if err := eg.Wait(); err != nil {
 		return err
 	}
+	return report(config, unformatted)
+}
+
+// report writes the files which are not formatted according to the check, diff and list
+// modes of config.
+func report(config *Config, unformatted []unformattedFile) error {
+	if config.rewrite() {
+		return nil
+	}
+	output := config.Output
+	if output == nil {
+		output = os.Stdout
+	}
+	sort.Slice(unformatted, func(i, j int) bool {
+		return unformatted[i].path < unformatted[j].path
+	})
+	for _, file := range unformatted {
+		if config.List || config.Check && !config.Diff {
+			if _, err := fmt.Fprintln(output, file.path); err != nil {
+				return err
+			}
+		}
+		if config.Diff {
+			if err := writeDiff(output, file); err != nil {
+				return err
+			}
+		}
+	}
+	if config.Check && len(unformatted) > 0 {
+		return fmt.Errorf("%w: %d files", ErrNotFormatted, len(unformatted))
+	}
 	return nil
 }
 
+// writeDiff writes the changes of the file as a unified diff, like gofmt -d.
+func writeDiff(w io.Writer, file unformattedFile) error {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(file.original)),
+		B:        difflib.SplitLines(string(file.formatted)),
+		FromFile: file.path + ".orig",
+		ToFile:   file.path,
+		Context:  3,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "diff -u %s.orig %s\n%s", file.path, file.path, diff)
+	return err
+}
+
 func (f *Format) excludeDir(path string) bool {
 	return f.exclude[path] ||
 		filepath.Base(path)[0] == '.' &&
@@ -98,22 +190,29 @@ func (f *Format) excludeFile(path string) bool {
 		filepath.Ext(path) != ".go"
 }
 
-func (f *Format) format(path string) error {
+// format formats the swag comments of the file, and returns it if they are not formatted.
+// The file is rewritten if rewrite is set.
+func (f *Format) format(path string, rewrite bool) (*unformattedFile, error) {
 	original, err := os.ReadFile(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	contents := make([]byte, len(original))
 	copy(contents, original)
 	formatted, err := f.formatter.Format(path, contents)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if bytes.Equal(original, formatted) {
 		// Skip write if no change
-		return nil
+		return nil, nil
+	}
+	if rewrite {
+		if err := write(path, formatted); err != nil {
+			return nil, err
+		}
 	}
-	return write(path, formatted)
+	return &unformattedFile{path: path, original: original, formatted: formatted}, nil
 }
 
 func write(path string, contents []byte) error {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,6 +75,52 @@ func TestFormat_WriteError(t *testing.T) {
 	os.Chmod(fx.basedir, 0755)
 }
 
+func TestFormat_Check(t *testing.T) {
+	fx := setup(t)
+	var output bytes.Buffer
+	err := New().Build(&Config{SearchDir: fx.basedir, Check: true, Output: &output})
+	assert.ErrorIs(t, err, ErrNotFormatted)
+	assert.EqualError(t, err, "swag comments are not formatted: 2 files")
+	assert.Equal(t, filepath.Join(fx.basedir, "api/api.go")+"\n"+filepath.Join(fx.basedir, "main.go")+"\n", output.String())
+	assert.False(t, fx.isFormatted("main.go"))
+	assert.False(t, fx.isFormatted("api/api.go"))
+
+	assert.NoError(t, New().Build(&Config{SearchDir: fx.basedir}))
+	output.Reset()
+	assert.NoError(t, New().Build(&Config{SearchDir: fx.basedir, Check: true, Output: &output}))
+	assert.Empty(t, output.String())
+}
+
+func TestFormat_Diff(t *testing.T) {
+	fx := setup(t)
+	var output bytes.Buffer
+	assert.NoError(t, New().Build(&Config{
+		SearchDir: fx.basedir,
+		Excludes:  filepath.Join(fx.basedir, "api"),
+		Diff:      true,
+		Output:    &output,
+	}))
+	assert.False(t, fx.isFormatted("main.go"))
+
+	path := filepath.Join(fx.basedir, "main.go")
+	assert.True(t, strings.HasPrefix(output.String(), "diff -u "+path+".orig "+path+"\n--- "+path+".orig\n+++ "+path+"\n@@ -1,13 +1,14 @@\n"))
+	assert.Contains(t, output.String(), "\n-\t\t// @title Swagger Example API\n")
+	assert.Contains(t, output.String(), "\n+// @title\t\tSwagger Example API\n")
+}
+
+func TestFormat_List(t *testing.T) {
+	fx := setup(t)
+	var output bytes.Buffer
+	assert.NoError(t, New().Build(&Config{
+		SearchDir: fx.basedir,
+		Excludes:  filepath.Join(fx.basedir, "api"),
+		List:      true,
+		Output:    &output,
+	}))
+	assert.Equal(t, filepath.Join(fx.basedir, "main.go")+"\n", output.String())
+	assert.False(t, fx.isFormatted("main.go"))
+}
+
 func TestFormat_InvalidSearchDir(t *testing.T) {
 	formatter := New()
 	assert.Error(t, formatter.Build(&Config{SearchDir: "no_such_dir"}))
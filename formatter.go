@@ -32,20 +32,94 @@ var skipChar = map[byte]byte{
 	'[': ']',
 }
 
+// MimeTypeStyle is the way the formatter writes the media types of @Accept and @Produce.
+type MimeTypeStyle int
+
+const (
+	// KeepMimeTypes leaves the media types as they are written.
+	KeepMimeTypes MimeTypeStyle = iota
+	// ExpandMimeTypes replaces the aliases like json by their media type.
+	ExpandMimeTypes
+	// CollapseMimeTypes replaces the media types having an alias by the alias.
+	CollapseMimeTypes
+)
+
 // Formatter implements a formatter for Go source files.
 type Formatter struct {
 	// debugging output goes here
 	debug Debugger
+
+	// canonicalAttributes writes the attributes of operations with their canonical casing
+	canonicalAttributes bool
+
+	// sortAttributes sorts the attributes of operations in a stable order
+	sortAttributes bool
+
+	// mimeTypeStyle is the way media types are written
+	mimeTypeStyle MimeTypeStyle
+
+	// normalizeRequired writes the required field of parameters as true or false
+	normalizeRequired bool
+
+	// quoteDescriptions quotes the descriptions of parameters, responses and headers
+	quoteDescriptions bool
 }
 
 // NewFormatter create a new formatter instance.
-func NewFormatter() *Formatter {
+func NewFormatter(options ...func(*Formatter)) *Formatter {
 	formatter := &Formatter{
 		debug: log.New(os.Stdout, "", log.LstdFlags),
 	}
+
+	for _, option := range options {
+		option(formatter)
+	}
+
 	return formatter
 }
 
+// SetCanonicalAttributes sets whether the attributes of operations are written with their
+// canonical casing, like @Success for @success.
+func SetCanonicalAttributes(enabled bool) func(*Formatter) {
+	return func(f *Formatter) {
+		f.canonicalAttributes = enabled
+	}
+}
+
+// SetSortAttributes sets whether the consecutive attributes of operations are sorted in a
+// stable order: Summary, Description, ID, Tags, Accept, Produce, Use, Security, Param,
+// Success, Failure, Response, Header, Link, Callback, Deprecated and Router. Other
+// attributes stay after the attribute they follow.
+func SetSortAttributes(enabled bool) func(*Formatter) {
+	return func(f *Formatter) {
+		f.sortAttributes = enabled
+	}
+}
+
+// SetMimeTypeStyle sets the way the media types of @Accept and @Produce are written.
+func SetMimeTypeStyle(style MimeTypeStyle) func(*Formatter) {
+	return func(f *Formatter) {
+		f.mimeTypeStyle = style
+	}
+}
+
+// SetNormalizeRequired sets whether the required field of @Param is written as true or
+// false, like the parser understands it. Values other than true, required and false are
+// left as they are.
+func SetNormalizeRequired(enabled bool) func(*Formatter) {
+	return func(f *Formatter) {
+		f.normalizeRequired = enabled
+	}
+}
+
+// SetQuoteDescriptions sets whether the unquoted descriptions of @Param, @Success,
+// @Failure, @Response and @Header are quoted.
+func SetQuoteDescriptions(enabled bool) func(*Formatter) {
+	return func(f *Formatter) {
+		f.quoteDescriptions = enabled
+	}
+}
+
 // Format formats swag comments in contents. It uses fileName to report errors
 // that happen during parsing of contents.
 func (f *Formatter) Format(fileName string, contents []byte) ([]byte, error) {
@@ -74,7 +148,7 @@ func (f *Formatter) Format(fileName string, contents []byte) ([]byte, error) {
 	edits := make(edits, 0, maxEdits)
 
 	for _, comment := range astFile.Comments {
-		formatFuncDoc(fileSet, comment.List, &edits)
+		f.formatFuncDoc(fileSet, comment.List, &edits)
 	}
 	formatted, err := imports.Process(fileName, edits.apply(contents), nil)
 	if err != nil {
@@ -109,27 +183,44 @@ func (edits edits) apply(contents []byte) []byte {
 
 // formatFuncDoc reformats the comment lines in commentList, and appends any
 // changes to the edit list.
-func formatFuncDoc(fileSet *token.FileSet, commentList []*ast.Comment, edits *edits) {
+func (f *Formatter) formatFuncDoc(fileSet *token.FileSet, commentList []*ast.Comment, edits *edits) {
 	// Building the edit list to format a comment block is a two-step process.
 	// First, we iterate over each comment line looking for Swag attributes. In
-	// each one we find, we replace alignment whitespace with a tab character,
-	// then write the result into a tab writer.
+	// each one we find, we normalize it and replace alignment whitespace with a
+	// tab character, then write the result into a tab writer.
+
+	var attributes []swagAttribute
+
+	for commentIndex := 0; commentIndex < len(commentList); commentIndex++ {
+		comment := commentList[commentIndex]
+
+		attr, body, found := swagComment(comment.Text)
+		if !found {
+			continue
+		}
 
-	linesToComments := make(map[int]int, len(commentList))
+		attribute := swagAttribute{commentIndex: commentIndex, attr: attr, body: body}
+		if isBlockAttribute(comment.Text) {
+			for commentIndex+1 < len(commentList) && isBlockLine(commentList[commentIndex+1].Text) {
+				commentIndex++
+				attribute.block = append(attribute.block, commentList[commentIndex].Text)
+			}
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	f.normalize(commentList, attributes)
 
 	buffer := &bytes.Buffer{}
 	w := tabwriter.NewWriter(buffer, 1, 4, 1, '\t', 0)
 
-	for commentIndex, comment := range commentList {
-		text := comment.Text
-		if attr, body, found := swagComment(text); found {
-			formatted := "//\t" + attr
-			if body != "" {
-				formatted += "\t" + splitComment2(attr, body)
-			}
-			_, _ = fmt.Fprintln(w, formatted)
-			linesToComments[len(linesToComments)] = commentIndex
+	for _, attribute := range attributes {
+		formatted := "//\t" + attribute.attr
+		if attribute.body != "" {
+			formatted += "\t" + splitComment2(attribute.attr, attribute.body)
 		}
+		_, _ = fmt.Fprintln(w, formatted)
 	}
 
 	// Once we've loaded all of the comment lines to be aligned into the tab
@@ -142,13 +233,25 @@ func formatFuncDoc(fileSet *token.FileSet, commentList []*ast.Comment, edits *ed
 	// comment line, and use the combination to describe the edit that needs to
 	// be made to the original input.
 	formattedComments := bytes.Split(buffer.Bytes(), []byte("\n"))
-	for lineIndex, commentIndex := range linesToComments {
-		comment := commentList[commentIndex]
+	for lineIndex, attribute := range attributes {
+		comment := commentList[attribute.commentIndex]
 		*edits = append(*edits, edit{
 			begin:       fileSet.Position(comment.Pos()).Offset,
 			end:         fileSet.Position(comment.End()).Offset,
 			replacement: formattedComments[lineIndex],
 		})
+
+		// the lines of the blocks follow their attributes when they are sorted
+		for i, line := range attribute.block {
+			comment := commentList[attribute.commentIndex+1+i]
+			if comment.Text != line {
+				*edits = append(*edits, edit{
+					begin:       fileSet.Position(comment.Pos()).Offset,
+					end:         fileSet.Position(comment.End()).Offset,
+					replacement: []byte(line),
+				})
+			}
+		}
 	}
 }
 
@@ -191,3 +294,273 @@ func swagComment(comment string) (string, string, bool) {
 	}
 	return matches[1], matches[2], true
 }
+
+// swagAttribute is an attribute of a swag comment line.
+type swagAttribute struct {
+	commentIndex int
+	attr         string
+	body         string
+
+	// block are the comment lines of the block of the attribute, following it
+	block []string
+}
+
+// operationAttributeOrder is the order of the operation attributes sorted by the formatter.
+var operationAttributeOrder = []string{
+	summaryAttr, descriptionAttr, descriptionMarkdownAttr, idAttr, tagsAttr, acceptAttr, produceAttr,
+	useAttr, securityAttr, paramAttr, successAttr, failureAttr, responseAttr, headerAttr, linkAttr,
+	callbackAttr, deprecatedAttr, routerAttr, deprecatedRouterAttr, webhookAttr,
+}
+
+var paramAttributesPattern = regexp.MustCompile(`(^|\s+)[A-Za-z.]+\(`)
+
+// normalize applies the normalizations enabled in the formatter to the attributes of a
+// comment block.
+func (f *Formatter) normalize(commentList []*ast.Comment, attributes []swagAttribute) {
+	if !f.canonicalAttributes && !f.sortAttributes && f.mimeTypeStyle == KeepMimeTypes &&
+		!f.normalizeRequired && !f.quoteDescriptions {
+		return
+	}
+
+	lines := make([]string, 0, len(commentList))
+	for _, comment := range commentList {
+		lines = append(lines, strings.TrimSpace(strings.TrimLeft(comment.Text, "/")))
+	}
+
+	// the casing and order of the general API info are left as they are
+	operation := !isGeneralAPIComment(lines)
+
+	for i := range attributes {
+		attributes[i].attr, attributes[i].body = f.normalizeAttribute(attributes[i].attr, attributes[i].body, operation)
+	}
+
+	if f.sortAttributes && operation {
+		sortAttributes(attributes)
+	}
+}
+
+func (f *Formatter) normalizeAttribute(attr, body string, operation bool) (string, string) {
+	lowerAttr := strings.ToLower(attr)
+
+	if f.canonicalAttributes && operation {
+		for _, name := range operationAttributeNames {
+			if strings.ToLower(name) == lowerAttr {
+				attr = name
+			}
+		}
+	}
+
+	switch lowerAttr {
+	case acceptAttr, produceAttr:
+		body = f.normalizeMimeTypes(body)
+	case paramAttr:
+		body = f.normalizeParam(body)
+	case successAttr, failureAttr, responseAttr:
+		if f.quoteDescriptions {
+			body = quoteResponseDescription(body)
+		}
+	case headerAttr:
+		if f.quoteDescriptions {
+			fields, rest := cutFields(body, 3)
+			if len(fields) == 3 && rest != "" {
+				body = strings.Join(fields, " ") + " " + quoteDescription(rest)
+			}
+		}
+	}
+
+	return attr, body
+}
+
+func (f *Formatter) normalizeMimeTypes(body string) string {
+	if f.mimeTypeStyle == KeepMimeTypes || body == "" {
+		return body
+	}
+
+	mimeTypes := strings.Split(body, ",")
+	for i, mimeType := range mimeTypes {
+		mimeType = strings.TrimSpace(mimeType)
+
+		for alias, fullMimeType := range mimeTypeAliases {
+			if f.mimeTypeStyle == ExpandMimeTypes && mimeType == alias {
+				mimeType = fullMimeType
+
+				break
+			}
+
+			if f.mimeTypeStyle == CollapseMimeTypes && mimeType == fullMimeType {
+				mimeType = alias
+
+				break
+			}
+		}
+
+		mimeTypes[i] = mimeType
+	}
+
+	return strings.Join(mimeTypes, ",")
+}
+
+func (f *Formatter) normalizeParam(body string) string {
+	fields, rest := cutFields(body, 4)
+	if len(fields) != 4 {
+		return body
+	}
+
+	if f.normalizeRequired {
+		// other values, like typos, are left as they are
+		switch strings.ToLower(fields[3]) {
+		case "true", requiredLabel:
+			fields[3] = "true"
+		case "false":
+			fields[3] = "false"
+		}
+	}
+
+	if f.quoteDescriptions {
+		description, attributes := rest, ""
+		if loc := paramAttributesPattern.FindStringIndex(rest); loc != nil {
+			description, attributes = rest[:loc[0]], rest[loc[0]:]
+		}
+
+		rest = quoteDescription(description) + attributes
+	}
+
+	return strings.TrimSpace(strings.Join(fields, " ") + " " + rest)
+}
+
+// quoteResponseDescription quotes the description of a response, which follows its code
+// and its type if any, and precedes its options.
+func quoteResponseDescription(body string) string {
+	// the block marker follows the options
+	if line, ok := cutBlockMarker(body); ok {
+		return quoteResponseDescription(line) + " " + blockMarker
+	}
+
+	line, _, err := splitResponseOptions(body)
+	if err != nil || strings.Contains(line, refPrefix) {
+		return body
+	}
+
+	options := body[len(line):]
+
+	fields, rest := cutFields(line, 1)
+	if strings.HasPrefix(rest, "{") {
+		var typeFields []string
+
+		typeFields, rest = cutFields(rest, 2)
+		fields = append(fields, typeFields...)
+	}
+
+	if len(fields) == 0 || rest == "" {
+		return body
+	}
+
+	return strings.Join(fields, " ") + " " + quoteDescription(rest) + options
+}
+
+// quoteDescription quotes the description unless it is quoted, holds quotes or is the
+// block marker.
+func quoteDescription(description string) string {
+	description = strings.TrimSpace(description)
+	if description == "" || description == blockMarker || strings.Contains(description, `"`) {
+		return description
+	}
+
+	return `"` + description + `"`
+}
+
+// cutFields returns the first n fields of body, the spaces inside quotes and brackets not
+// separating fields, and the rest of body.
+func cutFields(body string, n int) ([]string, string) {
+	var fields []string
+
+	for len(fields) < n {
+		body = strings.TrimLeft(body, " \t")
+		if body == "" {
+			break
+		}
+
+		end := fieldEnd(body)
+		fields = append(fields, body[:end])
+		body = body[end:]
+	}
+
+	return fields, strings.TrimSpace(body)
+}
+
+func fieldEnd(s string) int {
+	for i := 0; i < len(s); i++ {
+		if skipEnd, ok := skipChar[s[i]]; ok {
+			skipStart, n := s[i], 1
+			for i++; i < len(s); i++ {
+				if skipStart != skipEnd && s[i] == skipStart {
+					n++
+				} else if s[i] == skipEnd {
+					n--
+					if n == 0 {
+						break
+					}
+				}
+			}
+		} else if s[i] == ' ' || s[i] == '\t' {
+			return i
+		}
+	}
+
+	return len(s)
+}
+
+// sortAttributes sorts each run of consecutive attributes in the order of
+// operationAttributeOrder, an attribute out of it staying after the one it follows. The
+// attributes are sorted with the lines of their blocks, and the runs holding a template
+// are left unchanged.
+func sortAttributes(attributes []swagAttribute) {
+	for start := 0; start < len(attributes); {
+		end := start + 1
+		for end < len(attributes) &&
+			attributes[end].commentIndex == attributes[end-1].commentIndex+1+len(attributes[end-1].block) {
+			end++
+		}
+
+		sortAttributeRun(attributes[start:end])
+
+		start = end
+	}
+}
+
+func sortAttributeRun(run []swagAttribute) {
+	type rankedAttribute struct {
+		rank      int
+		attribute swagAttribute
+	}
+
+	ranked := make([]rankedAttribute, 0, len(run))
+	rank := -1
+
+	for _, attribute := range run {
+		lowerAttr := strings.ToLower(attribute.attr)
+		if lowerAttr == templateAttr {
+			return
+		}
+
+		for i, name := range operationAttributeOrder {
+			if name == lowerAttr {
+				rank = i
+			}
+		}
+
+		ranked = append(ranked, rankedAttribute{rank: rank, attribute: attribute})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].rank < ranked[j].rank
+	})
+
+	// the attributes move with their blocks, shifting the lines following them
+	commentIndex := run[0].commentIndex
+	for i := range run {
+		run[i] = ranked[i].attribute
+		run[i].commentIndex = commentIndex
+		commentIndex += 1 + len(run[i].block)
+	}
+}
@@ -1,9 +1,11 @@
 package swag
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -348,3 +350,131 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 `
 	testFormat(t, "db.sql.go", contents, want)
 }
+
+func Test_FormatNormalize(t *testing.T) {
+	contents := `package api
+
+// @title Normalize API
+// @accept application/json
+
+// GetUser returns a user.
+//
+// @router /users/{id} [get]
+// @success 200 {object} string User found
+// @param id path int required The user id minimum(1)
+// @param verbose query bool False Verbose output
+// @param fields query string ture Fields to return
+// @x-order 1
+// @summary Get a user
+// @produce application/json,text/csv,application/xml
+// @failure 404 Not found
+// @header 200 {string} X-Request-ID Request identifier
+func GetUser() {}
+`
+	formatter := NewFormatter(
+		SetCanonicalAttributes(true),
+		SetSortAttributes(true),
+		SetMimeTypeStyle(CollapseMimeTypes),
+		SetNormalizeRequired(true),
+		SetQuoteDescriptions(true),
+	)
+	got, err := formatter.Format("api.go", []byte(contents))
+	assert.NoError(t, err)
+	assert.Equal(t, `package api
+
+//	@title	Normalize API
+//	@accept	json
+
+// GetUser returns a user.
+//
+//	@Summary	Get a user
+//	@Produce	json,text/csv,application/xml
+//	@Param		id		path	int		true	"The user id"	minimum(1)
+//	@Param		verbose	query	bool	false	"Verbose output"
+//	@Param		fields	query	string	ture	"Fields to return"
+//	@x-order	1
+//	@Success	200	{object}	string	"User found"
+//	@Failure	404	"Not found"
+//	@Header		200	{string}	X-Request-ID	"Request identifier"
+//	@Router		/users/{id} [get]
+func GetUser() {}
+`, string(got))
+
+	// every normalization is off by default
+	got, err = NewFormatter().Format("api.go", []byte(contents))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "//	@router		/users/{id} [get]\n")
+	assert.Contains(t, string(got), "//	@param		verbose	query		bool	False		Verbose	output\n")
+
+	got, err = NewFormatter(SetMimeTypeStyle(ExpandMimeTypes)).Format("api.go", []byte(`package api
+
+// @Produce json, plain
+// @Router /a [get]
+func A() {}
+`))
+	assert.NoError(t, err)
+	assert.Contains(t, string(got), "// @Produce	application/json,text/plain\n")
+}
+
+func Test_sortAttributesKeepsTemplates(t *testing.T) {
+	attributes := []swagAttribute{
+		{commentIndex: 0, attr: "@Template", body: "authed"},
+		{commentIndex: 1, attr: "@Failure", body: "401"},
+		{commentIndex: 2, attr: "@Security", body: "ApiKeyAuth"},
+		{commentIndex: 4, attr: "@Router", body: "/a [get]"},
+		{commentIndex: 5, attr: "@Summary", body: "A"},
+	}
+	sortAttributes(attributes)
+	assert.Equal(t, []swagAttribute{
+		{commentIndex: 0, attr: "@Template", body: "authed"},
+		{commentIndex: 1, attr: "@Failure", body: "401"},
+		{commentIndex: 2, attr: "@Security", body: "ApiKeyAuth"},
+		{commentIndex: 4, attr: "@Summary", body: "A"},
+		{commentIndex: 5, attr: "@Router", body: "/a [get]"},
+	}, attributes)
+}
+
+func Test_FormatKeepsBlocks(t *testing.T) {
+	contents, err := os.ReadFile("testdata/blocks/api/api.go")
+	require.NoError(t, err)
+
+	formatter := NewFormatter(
+		SetCanonicalAttributes(true),
+		SetSortAttributes(true),
+		SetNormalizeRequired(true),
+		SetQuoteDescriptions(true),
+	)
+	got, err := formatter.Format("api.go", contents)
+	assert.NoError(t, err)
+	assert.Equal(t, string(contents), string(got))
+
+	got, err = formatter.Format("api.go", []byte(`package api
+
+// A is an operation.
+//
+//	@router /a [get]
+//	@success 200 {object} string
+//	@summary A
+//	@description |
+//	  Sorted with its block,
+//	  which is kept.
+//	@failure 400 |
+//	  The block of the failure.
+func A() {}
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, `package api
+
+// A is an operation.
+//
+//	@Summary		A
+//	@Description	|
+//	  Sorted with its block,
+//	  which is kept.
+//	@Success		200	{object}	string
+//	@Failure		400	|
+//	  The block of the failure.
+//	@Router			/a [get]
+func A() {}
+`, string(got))
+}
@@ -0,0 +1,741 @@
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// clientTypeFallback is used for schemas which have no importable Go type behind them.
+const clientTypeFallback = "json.RawMessage"
+
+func (g *Gen) writeClientSwagger(config *Config, swagger *spec.Swagger) error {
+	packageName := "client"
+	if config.InstanceName != swag.Name {
+		packageName = clientIdentifier(strings.ToLower(config.InstanceName), false) + "client"
+	}
+
+	clientDir := filepath.Join(config.OutputDir, packageName)
+	if err := os.MkdirAll(clientDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	filename := "client.go"
+	if config.State != "" {
+		filename = config.State + "_" + filename
+	}
+
+	clientFileName := filepath.Join(clientDir, filename)
+
+	src := newClientWriter(packageName, swagger, g.definitionTypes).write()
+
+	err := g.writeFile(g.formatSource(src), clientFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create client.go at %+v", clientFileName)
+
+	return nil
+}
+
+// clientImport is a package imported by the generated client for its model types.
+type clientImport struct {
+	alias string
+	path  string
+}
+
+// clientWriter renders a Go client package for the operations of a swagger document,
+// reusing the Go types the definitions were generated from.
+type clientWriter struct {
+	packageName     string
+	swagger         *spec.Swagger
+	definitionTypes map[string]*swag.TypeSpecDef
+
+	imports map[string]*clientImport
+	aliases map[string]bool
+	methods map[string]bool
+
+	buffer bytes.Buffer
+}
+
+func newClientWriter(packageName string, swagger *spec.Swagger, definitionTypes map[string]*swag.TypeSpecDef) *clientWriter {
+	return &clientWriter{
+		packageName:     packageName,
+		swagger:         swagger,
+		definitionTypes: definitionTypes,
+		imports:         make(map[string]*clientImport),
+		aliases:         make(map[string]bool),
+		methods:         make(map[string]bool),
+	}
+}
+
+func (w *clientWriter) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&w.buffer, format, args...)
+}
+
+func (w *clientWriter) write() []byte {
+	var operations bytes.Buffer
+
+	// operations are rendered first, so that the imports they need are known
+	if w.swagger.Paths != nil {
+		paths := make([]string, 0, len(w.swagger.Paths.Paths))
+		for path := range w.swagger.Paths.Paths {
+			paths = append(paths, path)
+		}
+
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			item := w.swagger.Paths.Paths[path]
+			for _, method := range operationMethods {
+				operation := swag.PathItemOperation(&item, method)
+				if operation == nil {
+					continue
+				}
+
+				w.writeOperation(method, path, operation)
+			}
+		}
+	}
+
+	operations.Write(w.buffer.Bytes())
+	w.buffer.Reset()
+
+	w.printf("// Package %s Code generated by swaggo/swag. DO NOT EDIT\n", w.packageName)
+	w.printf("package %s\n\n", w.packageName)
+	w.printf("import (\n")
+
+	for _, path := range []string{"bytes", "context", "encoding/json", "fmt", "io", "mime/multipart", "net/http", "net/url", "strings"} {
+		w.printf("\t%q\n", path)
+	}
+
+	if len(w.imports) > 0 {
+		w.printf("\n")
+
+		paths := make([]string, 0, len(w.imports))
+		for path := range w.imports {
+			paths = append(paths, path)
+		}
+
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			w.printf("\t%s %q\n", w.imports[path].alias, path)
+		}
+	}
+
+	w.printf(")\n\n")
+	w.printf(clientRuntime, w.defaultBaseURL())
+	w.buffer.Write(operations.Bytes())
+
+	return w.buffer.Bytes()
+}
+
+func (w *clientWriter) defaultBaseURL() string {
+	if w.swagger.Host == "" {
+		return w.swagger.BasePath
+	}
+
+	scheme := "http"
+	for _, s := range w.swagger.Schemes {
+		if s == "https" {
+			scheme = s
+
+			break
+		}
+	}
+
+	return scheme + "://" + w.swagger.Host + w.swagger.BasePath
+}
+
+// clientParam is a parameter of an operation rendered as a field of its params struct.
+type clientParam struct {
+	field  string
+	goType string
+	param  *spec.Parameter
+}
+
+func (w *clientWriter) writeOperation(method, path string, operation *spec.Operation) {
+	name := w.methodName(method, path, operation)
+
+	var (
+		params  []clientParam
+		fields  = make(map[string]bool)
+		hasFile bool
+	)
+
+	for i := range operation.Parameters {
+		param := resolveParameter(w.swagger, &operation.Parameters[i])
+
+		field := clientIdentifier(param.Name, true)
+		if param.In == "body" {
+			field = "Body"
+		}
+
+		for fields[field] {
+			field += clientIdentifier(param.In, true)
+		}
+
+		fields[field] = true
+
+		goType := w.parameterType(param)
+		if param.In == "formData" && param.Type == "file" {
+			hasFile = true
+		}
+
+		params = append(params, clientParam{field: field, goType: goType, param: param})
+	}
+
+	resultType := w.resultType(operation)
+
+	if len(params) > 0 {
+		w.printf("// %sParams holds the parameters of %s.\n", name, name)
+		w.printf("type %sParams struct {\n", name)
+
+		for _, p := range params {
+			if p.param.Description != "" {
+				for _, line := range strings.Split(p.param.Description, "\n") {
+					w.printf("\t// %s\n", line)
+				}
+			}
+
+			w.printf("\t%s %s\n", p.field, p.goType)
+		}
+
+		w.printf("}\n\n")
+	}
+
+	comment := name + " calls " + method + " " + path + "."
+	if operation.Summary != "" {
+		comment = name + " " + strings.TrimSuffix(operation.Summary, ".") + "."
+	}
+
+	w.printf("// %s\n", comment)
+
+	if operation.Deprecated {
+		w.printf("//\n// Deprecated: the operation is deprecated.\n")
+	}
+
+	paramsArg := ""
+	if len(params) > 0 {
+		paramsArg = ", params " + name + "Params"
+	}
+
+	if resultType != "" {
+		w.printf("func (c *Client) %s(ctx context.Context%s) (%s, error) {\n", name, paramsArg, resultType)
+	} else {
+		w.printf("func (c *Client) %s(ctx context.Context%s) error {\n", name, paramsArg)
+	}
+
+	w.printf("\treq := newRequest(%q, %q)\n", method, path)
+
+	if operation.Security != nil && len(operation.Security) == 0 {
+		w.printf("\treq.anonymous = true\n")
+	}
+
+	if len(operation.Produces) > 0 {
+		w.printf("\treq.header.Set(\"Accept\", %q)\n", strings.Join(operation.Produces, ", "))
+	}
+
+	for _, p := range params {
+		value := "params." + p.field
+		optional := !p.param.Required && strings.HasPrefix(p.goType, "*")
+
+		switch p.param.In {
+		case "path":
+			w.printf("\treq.path = pathParam(req.path, %q, %s)\n", p.param.Name, value)
+		case "query", "header", "formData":
+			target := map[string]string{"query": "req.query", "header": "req.header", "formData": "req.form"}[p.param.In]
+
+			switch {
+			case p.param.Type == "file":
+				w.printf("\tif %s != nil {\n\t\treq.files[%q] = %s\n\t}\n", value, p.param.Name, value)
+			case p.param.Type == "array":
+				w.printf("\tif len(%s) > 0 {\n\t\taddValues(%s, %q, %s, %q)\n\t}\n", value, target, p.param.Name, value, p.param.CollectionFormat)
+			case optional:
+				w.printf("\tif %s != nil {\n\t\t%s.Set(%q, fmt.Sprint(*%s))\n\t}\n", value, target, p.param.Name, value)
+			default:
+				w.printf("\t%s.Set(%q, fmt.Sprint(%s))\n", target, p.param.Name, value)
+			}
+		case "body":
+			w.printf("\tif %s != nil {\n\t\treq.body = %s\n\t}\n", value, value)
+		}
+	}
+
+	if hasFile {
+		w.printf("\treq.multipart = true\n")
+	}
+
+	if resultType != "" {
+		w.printf("\tvar result %s\n", strings.TrimPrefix(resultType, "*"))
+		w.printf("\tif err := c.do(ctx, req, &result); err != nil {\n\t\treturn nil, err\n\t}\n")
+		w.printf("\treturn &result, nil\n")
+	} else {
+		w.printf("\treturn c.do(ctx, req, nil)\n")
+	}
+
+	w.printf("}\n\n")
+}
+
+// methodName returns the unique method name of an operation, derived from its @ID or
+// from its method and path.
+func (w *clientWriter) methodName(method, path string, operation *spec.Operation) string {
+	name := clientIdentifier(operation.ID, true)
+	if name == "" {
+		name = clientIdentifier(strings.ToLower(method)+" "+path, true)
+	}
+
+	unique := name
+	for i := 2; w.methods[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	w.methods[unique] = true
+
+	return unique
+}
+
+// resultType returns the Go type of the first successful response which has a schema.
+func (w *clientWriter) resultType(operation *spec.Operation) string {
+	if operation.Responses == nil {
+		return ""
+	}
+
+	codes := make([]int, 0, len(operation.Responses.StatusCodeResponses))
+	for code := range operation.Responses.StatusCodeResponses {
+		if code >= 200 && code < 300 {
+			codes = append(codes, code)
+		}
+	}
+
+	sort.Ints(codes)
+
+	for _, code := range codes {
+		response := operation.Responses.StatusCodeResponses[code]
+		resolved := resolveResponse(w.swagger, &response)
+
+		if resolved.Schema != nil {
+			return "*" + w.schemaType(resolved.Schema)
+		}
+	}
+
+	return ""
+}
+
+func (w *clientWriter) parameterType(param *spec.Parameter) string {
+	var goType string
+
+	switch {
+	case param.In == "body":
+		return "*" + w.schemaType(param.Schema)
+	case param.Type == "file":
+		return "io.Reader"
+	case param.Type == "array":
+		itemType := "string"
+		if param.Items != nil {
+			itemType = clientPrimitiveType(param.Items.Type, param.Items.Format)
+		}
+
+		return "[]" + itemType
+	default:
+		goType = clientPrimitiveType(param.Type, param.Format)
+	}
+
+	if !param.Required {
+		return "*" + goType
+	}
+
+	return goType
+}
+
+// schemaType returns the Go type for schema, importing the packages of referenced definitions.
+func (w *clientWriter) schemaType(schema *spec.Schema) string {
+	if schema == nil {
+		return "any"
+	}
+
+	if name := definitionName(schema); name != "" {
+		return w.definitionType(name)
+	}
+
+	if len(schema.AllOf) > 0 {
+		return clientTypeFallback
+	}
+
+	if len(schema.Type) == 0 {
+		return "any"
+	}
+
+	switch schema.Type[0] {
+	case "array":
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return "[]" + w.schemaType(schema.Items.Schema)
+		}
+
+		return "[]any"
+	case "object":
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "map[string]" + w.schemaType(schema.AdditionalProperties.Schema)
+		}
+
+		if len(schema.Properties) > 0 {
+			return clientTypeFallback
+		}
+
+		return "map[string]any"
+	}
+
+	return clientPrimitiveType(schema.Type[0], schema.Format)
+}
+
+// definitionType returns the qualified Go type the definition was generated from, or
+// json.RawMessage if there is none which can be imported.
+func (w *clientWriter) definitionType(name string) string {
+	typeSpecDef, ok := w.definitionTypes[name]
+	if !ok || typeSpecDef == nil || typeSpecDef.TypeSpec == nil || typeSpecDef.File == nil {
+		return clientTypeFallback
+	}
+
+	typeName := typeSpecDef.Name()
+	switch {
+	case typeSpecDef.PkgPath == "",
+		typeSpecDef.ParentSpec != nil,
+		typeSpecDef.TypeSpec.TypeParams != nil,
+		typeSpecDef.File.Name.Name == "main",
+		typeName == "" || !unicode.IsUpper([]rune(typeName)[0]):
+		return clientTypeFallback
+	}
+
+	imp, ok := w.imports[typeSpecDef.PkgPath]
+	if !ok {
+		alias := clientIdentifier(typeSpecDef.File.Name.Name, false)
+		for i := 2; w.aliases[alias] || alias == w.packageName || clientReservedAliases[alias]; i++ {
+			alias = clientIdentifier(typeSpecDef.File.Name.Name, false) + strconv.Itoa(i)
+		}
+
+		imp = &clientImport{alias: alias, path: typeSpecDef.PkgPath}
+		w.imports[typeSpecDef.PkgPath] = imp
+		w.aliases[alias] = true
+	}
+
+	return imp.alias + "." + typeName
+}
+
+// clientReservedAliases are names which are already used in the generated client.
+var clientReservedAliases = map[string]bool{
+	"bytes": true, "context": true, "json": true, "fmt": true, "io": true, "multipart": true,
+	"http": true, "url": true, "strings": true, "req": true, "params": true, "result": true, "c": true,
+}
+
+func clientPrimitiveType(schemaType, format string) string {
+	switch schemaType {
+	case "integer":
+		switch format {
+		case "int32":
+			return "int32"
+		case "int64":
+			return "int64"
+		}
+
+		return "int"
+	case "number":
+		if format == "float" || format == "float32" {
+			return "float32"
+		}
+
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		return "string"
+	}
+
+	return "any"
+}
+
+// clientIdentifier converts name into a Go identifier, e.g. get-user_by id => GetUserByID.
+func clientIdentifier(name string, exported bool) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+
+	for i, part := range parts {
+		if !exported && i == 0 {
+			sb.WriteString(strings.ToLower(part))
+
+			continue
+		}
+
+		if upper := strings.ToUpper(part); upper == "ID" || upper == "URL" || upper == "API" || upper == "HTTP" {
+			sb.WriteString(upper)
+
+			continue
+		}
+
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	identifier := sb.String()
+	if identifier != "" && unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "Op" + identifier
+	}
+
+	return identifier
+}
+
+const clientRuntime = `// DefaultBaseURL is the base URL of the API declared in the documentation.
+const DefaultBaseURL = %q
+
+// Client calls the API operations.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	authorize  func(*http.Request) error
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to send requests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithAuthorizer sets a function which authorizes every request of a secured operation.
+func WithAuthorizer(authorize func(*http.Request) error) Option {
+	return func(c *Client) {
+		c.authorize = authorize
+	}
+}
+
+// WithBearerToken authorizes requests with the given bearer token.
+func WithBearerToken(token string) Option {
+	return WithAuthorizer(func(r *http.Request) error {
+		r.Header.Set("Authorization", "Bearer "+token)
+
+		return nil
+	})
+}
+
+// WithBasicAuth authorizes requests with the given username and password.
+func WithBasicAuth(username, password string) Option {
+	return WithAuthorizer(func(r *http.Request) error {
+		r.SetBasicAuth(username, password)
+
+		return nil
+	})
+}
+
+// WithAPIKey authorizes requests with an api key sent in the given header or query parameter.
+func WithAPIKey(in, name, value string) Option {
+	return WithAuthorizer(func(r *http.Request) error {
+		if in == "query" {
+			query := r.URL.Query()
+			query.Set(name, value)
+			r.URL.RawQuery = query.Encode()
+
+			return nil
+		}
+
+		r.Header.Set(name, value)
+
+		return nil
+	})
+}
+
+// New creates a new Client, DefaultBaseURL is used when baseURL is empty.
+func New(baseURL string, options ...Option) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return c
+}
+
+// APIError is returned when the server responds with a status code other than 2xx.
+type APIError struct {
+	StatusCode int
+	Body       []byte
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("unexpected status code %%d: %%s", e.StatusCode, strings.TrimSpace(string(e.Body)))
+}
+
+type request struct {
+	method    string
+	path      string
+	query     url.Values
+	header    http.Header
+	form      url.Values
+	files     map[string]io.Reader
+	body      any
+	multipart bool
+	anonymous bool
+}
+
+func newRequest(method, path string) *request {
+	return &request{
+		method: method,
+		path:   path,
+		query:  url.Values{},
+		header: http.Header{},
+		form:   url.Values{},
+		files:  map[string]io.Reader{},
+	}
+}
+
+func pathParam(path, name string, value any) string {
+	return strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(fmt.Sprint(value)))
+}
+
+func addValues[T any](target interface{ Add(string, string) }, name string, values []T, collectionFormat string) {
+	if collectionFormat == "multi" {
+		for _, value := range values {
+			target.Add(name, fmt.Sprint(value))
+		}
+
+		return
+	}
+
+	separator := map[string]string{"ssv": " ", "tsv": "\t", "pipes": "|"}[collectionFormat]
+	if separator == "" {
+		separator = ","
+	}
+
+	texts := make([]string, 0, len(values))
+	for _, value := range values {
+		texts = append(texts, fmt.Sprint(value))
+	}
+
+	target.Add(name, strings.Join(texts, separator))
+}
+
+func (r *request) encodeBody() (io.Reader, string, error) {
+	switch {
+	case r.multipart || len(r.files) > 0:
+		var buf bytes.Buffer
+
+		writer := multipart.NewWriter(&buf)
+		for name, values := range r.form {
+			for _, value := range values {
+				if err := writer.WriteField(name, value); err != nil {
+					return nil, "", err
+				}
+			}
+		}
+
+		for name, file := range r.files {
+			part, err := writer.CreateFormFile(name, name)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if _, err = io.Copy(part, file); err != nil {
+				return nil, "", err
+			}
+		}
+
+		if err := writer.Close(); err != nil {
+			return nil, "", err
+		}
+
+		return &buf, writer.FormDataContentType(), nil
+	case len(r.form) > 0:
+		return strings.NewReader(r.form.Encode()), "application/x-www-form-urlencoded", nil
+	case r.body != nil:
+		b, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return bytes.NewReader(b), "application/json", nil
+	}
+
+	return nil, "", nil
+}
+
+func (c *Client) do(ctx context.Context, r *request, result any) error {
+	body, contentType, err := r.encodeBody()
+	if err != nil {
+		return err
+	}
+
+	target := c.baseURL + r.path
+	if len(r.query) > 0 {
+		target += "?" + r.query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, target, body)
+	if err != nil {
+		return err
+	}
+
+	for name, values := range r.header {
+		req.Header[name] = values
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if c.authorize != nil && !r.anonymous {
+		if err = c.authorize(req); err != nil {
+			return err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Body: data}
+	}
+
+	if result == nil || len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+
+	if text, ok := result.(*string); ok && !json.Valid(data) {
+		*text = string(data)
+
+		return nil
+	}
+
+	return json.Unmarshal(data, result)
+}
+
+`
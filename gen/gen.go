@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/format"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -27,15 +29,19 @@ var open = os.Open
 // DefaultOverridesFile is the location swagger will look for type overrides.
 const DefaultOverridesFile = ".swaggo"
 
-type genTypeWriter func(*Config, *spec.Swagger) error
+// OutputTypeWriter writes the swagger document for the given configuration in a specific format.
+type OutputTypeWriter func(*Config, *spec.Swagger) error
 
 // Gen presents a generate tool for swag.
 type Gen struct {
 	json          func(data any) ([]byte, error)
 	jsonIndent    func(data any) ([]byte, error)
 	jsonToYAML    func(data []byte) ([]byte, error)
-	outputTypeMap map[string]genTypeWriter
+	outputTypeMap map[string]OutputTypeWriter
 	debug         Debugger
+
+	// definitionTypes holds the type definitions behind the swagger definitions of the last build
+	definitionTypes map[string]*swag.TypeSpecDef
 }
 
 // Debugger is the interface that wraps the basic Printf method.
@@ -54,16 +60,54 @@ func New() *Gen {
 		debug:      log.New(os.Stdout, "", log.LstdFlags),
 	}
 
-	gen.outputTypeMap = map[string]genTypeWriter{
-		"go":   gen.writeDocSwagger,
-		"json": gen.writeJSONSwagger,
-		"yaml": gen.writeYAMLSwagger,
-		"yml":  gen.writeYAMLSwagger,
+	gen.outputTypeMap = map[string]OutputTypeWriter{
+		"go":     gen.writeDocSwagger,
+		"json":   gen.writeJSONSwagger,
+		"yaml":   gen.writeYAMLSwagger,
+		"yml":    gen.writeYAMLSwagger,
+		"html":   gen.writeHTMLSwagger,
+		"md":     gen.writeMarkdownSwagger,
+		"client": gen.writeClientSwagger,
+		"ts":     gen.writeTypeScriptSwagger,
 	}
 
 	return &gen
 }
 
+// RegisterOutputType registers a writer for the given output type, so that it can be
+// selected in Config.OutputTypes. Names are case-insensitive; registering a name twice
+// returns an error.
+func (g *Gen) RegisterOutputType(name string, writer OutputTypeWriter) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return fmt.Errorf("output type name is empty")
+	}
+
+	if writer == nil {
+		return fmt.Errorf("output type '%s' writer is nil", name)
+	}
+
+	if _, ok := g.outputTypeMap[name]; ok {
+		return fmt.Errorf("output type '%s' is already registered", name)
+	}
+
+	g.outputTypeMap[name] = writer
+
+	return nil
+}
+
+// OutputTypes returns the sorted names of all registered output types.
+func (g *Gen) OutputTypes() []string {
+	names := make([]string, 0, len(g.outputTypeMap))
+	for name := range g.outputTypeMap {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 // Config presents Gen configurations.
 type Config struct {
 	Debugger swag.Debugger
@@ -111,6 +155,9 @@ type Config struct {
 	// UseStructNames stick to the struct name instead of those ugly full-path names
 	UseStructNames bool
 
+	// DefinitionName the text/template naming the definitions, like {{.Type}}{{.TypeArgs}}
+	DefinitionName string
+
 	// ParseInternal whether swag should parse internal packages
 	ParseInternal bool
 
@@ -123,6 +170,10 @@ type Config struct {
 	// RequiredByDefault set validation required for all fields by default
 	RequiredByDefault bool
 
+	// GoDocDescriptions whether swag should use the Go doc comments of the operations as
+	// their summary and description when they have none
+	GoDocDescriptions bool
+
 	// OverridesFile defines global type overrides.
 	OverridesFile string
 
@@ -155,6 +206,32 @@ type Config struct {
 
 	// ParseGoPackages whether swag use golang.org/x/tools/go/packages to parse source.
 	ParseGoPackages bool
+
+	// KeepUnusedDefinitions whether swag should keep the definitions which no operation
+	// references, which are removed by default
+	KeepUnusedDefinitions bool
+
+	// Documents split the parsed API into several documents, each written with every
+	// output type under its own instance name. The API is written as a single document
+	// named InstanceName when empty.
+	Documents []Document
+
+	// DiagnosticsFormat writes the errors and warnings of the parsing in the format, text,
+	// json, sarif or github, the errors being returned as their count
+	DiagnosticsFormat string
+
+	// DiagnosticsOutput is where the diagnostics are written, stdout by default
+	DiagnosticsOutput io.Writer
+}
+
+// Document defines one of the documents built from a single parse.
+type Document struct {
+	// InstanceName names the document, like Config.InstanceName.
+	InstanceName string
+
+	// DocumentSelector selects the operations of the document, whose definitions are
+	// pruned to the ones reachable from them.
+	swag.DocumentSelector
 }
 
 // Build builds swagger json file  for given searchDir and mainAPIFile. Returns json.
@@ -166,6 +243,19 @@ func (g *Gen) Build(config *Config) error {
 		config.InstanceName = swag.Name
 	}
 
+	instanceNames := make(map[string]struct{}, len(config.Documents))
+	for _, document := range config.Documents {
+		if document.InstanceName == "" {
+			return fmt.Errorf("documents must have an instance name")
+		}
+
+		if _, ok := instanceNames[document.InstanceName]; ok {
+			return fmt.Errorf("document '%s' is declared twice", document.InstanceName)
+		}
+
+		instanceNames[document.InstanceName] = struct{}{}
+	}
+
 	searchDirs := strings.Split(config.SearchDir, ",")
 	if !config.ParseGoPackages { // packages.Load support pattern like ./...
 		for _, searchDir := range searchDirs {
@@ -183,23 +273,13 @@ func (g *Gen) Build(config *Config) error {
 		config.RightTemplateDelim = "}}"
 	}
 
-	var overrides map[string]string
-
-	if config.OverridesFile != "" {
-		overridesFile, err := open(config.OverridesFile)
-		if err != nil {
-			// Don't bother reporting if the default file is missing; assume there are no overrides
-			if !(config.OverridesFile == DefaultOverridesFile && os.IsNotExist(err)) {
-				return fmt.Errorf("could not open overrides file: %w", err)
-			}
-		} else {
-			g.debug.Printf("Using overrides from %s", config.OverridesFile)
+	overrides, err := readOverrides(config.OverridesFile)
+	if err != nil {
+		return err
+	}
 
-			overrides, err = parseOverrides(overridesFile)
-			if err != nil {
-				return err
-			}
-		}
+	if overrides != nil {
+		g.debug.Printf("Using overrides from %s", config.OverridesFile)
 	}
 
 	g.debug.Printf("Generate swagger docs....")
@@ -207,6 +287,8 @@ func (g *Gen) Build(config *Config) error {
 	p := swag.New(
 		swag.SetParseDependency(config.ParseDependency),
 		swag.SetUseStructName(config.UseStructNames),
+		swag.SetGoDocDescriptions(config.GoDocDescriptions),
+		swag.SetDefinitionNameTemplate(config.DefinitionName),
 		swag.SetMarkdownFileDirectory(config.MarkdownFilesDir),
 		swag.SetDebugger(config.Debugger),
 		swag.SetExcludedDirsAndFiles(config.Excludes),
@@ -228,16 +310,94 @@ func (g *Gen) Build(config *Config) error {
 	p.ParseFuncBody = config.ParseFuncBody
 	p.ParseGoPackages = config.ParseGoPackages
 
-	if err := p.ParseAPIMultiSearchDir(searchDirs, config.MainAPIFile, config.ParseDepth); err != nil {
+	if err := g.writeDiagnostics(config, p, p.ParseAPIMultiSearchDir(searchDirs, config.MainAPIFile, config.ParseDepth)); err != nil {
 		return err
 	}
 
-	swagger := p.GetSwagger()
+	for _, rename := range p.DefinitionRenames() {
+		g.debug.Printf("Named definition %s %s instead of %s, shared with other types", rename.TypeSpec.FullPath(), rename.Rename, rename.Name)
+	}
+
+	if !config.KeepUnusedDefinitions {
+		g.reportDefinitions(p.PruneUnusedDefinitions())
+	}
+
+	g.definitionTypes = p.GetDefinitionTypeSpecs()
 
 	if err := os.MkdirAll(config.OutputDir, os.ModePerm); err != nil {
 		return err
 	}
 
+	if len(config.Documents) == 0 {
+		return g.writeOutputTypes(config, p.GetSwagger())
+	}
+
+	for _, document := range config.Documents {
+		documentConfig := *config
+		documentConfig.InstanceName = document.InstanceName
+
+		swagger := p.SelectDocument(document.DocumentSelector)
+		if len(swagger.Paths.Paths) == 0 {
+			g.debug.Printf("warning: document '%s' has no operation", document.InstanceName)
+		}
+
+		if err := g.writeOutputTypes(&documentConfig, swagger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeDiagnostics writes the diagnostics of the parsing when a format is configured, and
+// returns the error of the parsing, only counting its diagnostics once they are written.
+func (g *Gen) writeDiagnostics(config *Config, p *swag.Parser, err error) error {
+	if config.DiagnosticsFormat == "" {
+		return err
+	}
+
+	diagnostics := p.Diagnostics()
+
+	var diagnostic swag.Diagnostic
+	if errors.As(err, &diagnostic) {
+		diagnostics = append(diagnostics, diagnostic)
+	} else if err != nil && !errors.As(err, new(swag.Diagnostics)) {
+		return err
+	}
+
+	output := config.DiagnosticsOutput
+	if output == nil {
+		output = os.Stdout
+	}
+
+	if werr := swag.WriteDiagnostics(output, config.DiagnosticsFormat, diagnostics); werr != nil {
+		return werr
+	}
+
+	if errs := diagnostics.Errors(); len(errs) > 0 {
+		return fmt.Errorf("%d errors found", len(errs))
+	}
+
+	return nil
+}
+
+// reportDefinitions logs the definitions dropped from the document and the annotated
+// models which are not part of it.
+func (g *Gen) reportDefinitions(report *swag.DefinitionsReport) {
+	for _, dropped := range report.Dropped {
+		if dropped.TypeSpec != nil {
+			g.debug.Printf("Dropped unused definition %s (%s)", dropped.Name, dropped.TypeSpec.FullPath())
+		} else {
+			g.debug.Printf("Dropped unused definition %s", dropped.Name)
+		}
+	}
+
+	for _, typeSpec := range report.Unused {
+		g.debug.Printf("warning: annotated model %s is not used by any operation", typeSpec.FullPath())
+	}
+}
+
+func (g *Gen) writeOutputTypes(config *Config, swagger *spec.Swagger) error {
 	for _, outputType := range config.OutputTypes {
 		outputType = strings.ToLower(strings.TrimSpace(outputType))
 		if typeWriter, ok := g.outputTypeMap[outputType]; ok {
@@ -253,17 +413,7 @@ func (g *Gen) Build(config *Config) error {
 }
 
 func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "docs.go"
-
-	if config.State != "" {
-		filename = config.State + "_" + filename
-	}
-
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
-	}
-
-	docFileName := path.Join(config.OutputDir, filename)
+	docFileName := OutputFileName(config, "docs.go")
 
 	absOutputDir, err := filepath.Abs(config.OutputDir)
 	if err != nil {
@@ -296,17 +446,7 @@ func (g *Gen) writeDocSwagger(config *Config, swagger *spec.Swagger) error {
 }
 
 func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "swagger.json"
-
-	if config.State != "" {
-		filename = config.State + "_" + filename
-	}
-
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
-	}
-
-	jsonFileName := path.Join(config.OutputDir, filename)
+	jsonFileName := OutputFileName(config, "swagger.json")
 
 	b, err := g.jsonIndent(swagger)
 	if err != nil {
@@ -324,17 +464,7 @@ func (g *Gen) writeJSONSwagger(config *Config, swagger *spec.Swagger) error {
 }
 
 func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
-	var filename = "swagger.yaml"
-
-	if config.State != "" {
-		filename = config.State + "_" + filename
-	}
-
-	if config.InstanceName != swag.Name {
-		filename = config.InstanceName + "_" + filename
-	}
-
-	yamlFileName := path.Join(config.OutputDir, filename)
+	yamlFileName := OutputFileName(config, "swagger.yaml")
 
 	b, err := g.json(swagger)
 	if err != nil {
@@ -356,6 +486,20 @@ func (g *Gen) writeYAMLSwagger(config *Config, swagger *spec.Swagger) error {
 	return nil
 }
 
+// OutputFileName returns the path of the generated file with the given base name, prefixed
+// with the state and instance name of config when they are set.
+func OutputFileName(config *Config, filename string) string {
+	if config.State != "" {
+		filename = config.State + "_" + filename
+	}
+
+	if config.InstanceName != swag.Name {
+		filename = config.InstanceName + "_" + filename
+	}
+
+	return path.Join(config.OutputDir, filename)
+}
+
 func (g *Gen) writeFile(b []byte, file string) error {
 	f, err := os.Create(file)
 	if err != nil {
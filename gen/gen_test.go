@@ -973,3 +973,429 @@ func TestGen_StateUser(t *testing.T) {
 
 	assert.JSONEq(t, string(expectedJSON), string(jsonOutput))
 }
+
+func TestGen_RegisterOutputType(t *testing.T) {
+	g := New()
+
+	var called bool
+	assert.NoError(t, g.RegisterOutputType("Custom", func(config *Config, swagger *spec.Swagger) error {
+		called = true
+		assert.Equal(t, "Swagger Example API", swagger.Info.Title)
+
+		return nil
+	}))
+	assert.Contains(t, g.OutputTypes(), "custom")
+
+	assert.Error(t, g.RegisterOutputType("custom", func(*Config, *spec.Swagger) error { return nil }))
+	assert.Error(t, g.RegisterOutputType("json", func(*Config, *spec.Swagger) error { return nil }))
+	assert.Error(t, g.RegisterOutputType("", func(*Config, *spec.Swagger) error { return nil }))
+	assert.Error(t, g.RegisterOutputType("nil", nil))
+
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/docs",
+		OutputTypes:        []string{"custom"},
+		PropNamingStrategy: "",
+	}
+	assert.NoError(t, g.Build(config))
+	assert.True(t, called)
+}
+
+func TestGen_HTMLOutputType(t *testing.T) {
+	bundle := redocBundle
+	redocBundle = ""
+	t.Cleanup(func() {
+		redocBundle = bundle
+	})
+
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/docs",
+		OutputTypes:        []string{"html"},
+		PropNamingStrategy: "",
+		InstanceName:       "Custom",
+	}
+	assert.NoError(t, New().Build(config))
+
+	htmlFile := filepath.Join(config.OutputDir, "Custom_swagger.html")
+	t.Cleanup(func() {
+		_ = os.Remove(htmlFile)
+	})
+
+	b, err := os.ReadFile(htmlFile)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), "<title>Swagger Example API</title>")
+	assert.Contains(t, string(b), "Redoc.init({")
+	assert.Contains(t, string(b), `<script src="`+redocBundleURL+`"`)
+	assert.Contains(t, string(b), `"swagger":"2.0"`)
+}
+
+func TestGen_HTMLOutputTypeInlinesBundle(t *testing.T) {
+	bundle := redocBundle
+	redocBundle = `var Redoc = {init: function() {}}; "</script>";`
+	t.Cleanup(func() {
+		redocBundle = bundle
+	})
+
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/docs",
+		OutputTypes:        []string{"html"},
+		PropNamingStrategy: "",
+	}
+	assert.NoError(t, New().Build(config))
+
+	htmlFile := filepath.Join(config.OutputDir, "swagger.html")
+	t.Cleanup(func() {
+		_ = os.Remove(htmlFile)
+	})
+
+	b, err := os.ReadFile(htmlFile)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(b), `<script>var Redoc = {init: function() {}}; "<\/script>";</script>`)
+	assert.NotContains(t, string(b), redocBundleURL)
+	assert.Contains(t, string(b), "Redoc.init({")
+}
+
+func TestGen_MarkdownOutputType(t *testing.T) {
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/docs",
+		OutputTypes:        []string{"md"},
+		PropNamingStrategy: "",
+	}
+	assert.NoError(t, New().Build(config))
+
+	mdFile := filepath.Join(config.OutputDir, "swagger.md")
+	t.Cleanup(func() {
+		_ = os.Remove(mdFile)
+	})
+
+	b, err := os.ReadFile(mdFile)
+	require.NoError(t, err)
+
+	md := string(b)
+	assert.True(t, strings.HasPrefix(md, "# Swagger Example API\n"))
+	assert.Contains(t, md, "## Operations")
+	assert.Contains(t, md, "#### `GET /testapi/get-string-by-int/{some_id}` - Add a new pet to the store")
+	assert.Contains(t, md, "| some_id | path | integer (int64) | true | Some ID |")
+	assert.Contains(t, md, "| 400 | We need ID!! | [web.APIError](#definitions-web-apierror) |")
+	assert.Contains(t, md, "<a id=\"definitions-web-apierror\"></a>")
+	assert.Contains(t, md, "## Definitions")
+}
+
+func TestGen_markdownWriter(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Info: &spec.Info{InfoProps: spec.InfoProps{Title: "Pets", Version: "1.0"}},
+			Tags: []spec.Tag{{TagProps: spec.TagProps{Name: "pets", Description: "Pet operations"}}},
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+				"/pets": {PathItemProps: spec.PathItemProps{
+					Post: &spec.Operation{OperationProps: spec.OperationProps{
+						ID:      "createPet",
+						Summary: "Create a pet",
+						Tags:    []string{"pets"},
+						Parameters: []spec.Parameter{{
+							ParamProps: spec.ParamProps{Name: "pet", In: "body", Required: true, Schema: spec.RefSchema("#/definitions/model.Pet")},
+						}},
+						Responses: &spec.Responses{ResponsesProps: spec.ResponsesProps{
+							StatusCodeResponses: map[int]spec.Response{
+								201: *spec.NewResponse().WithDescription("Created").WithSchema(spec.RefSchema("#/definitions/model.Pet")),
+							},
+						}},
+					}},
+					Get: &spec.Operation{OperationProps: spec.OperationProps{
+						Summary: "List pets",
+						Parameters: []spec.Parameter{
+							*spec.QueryParam("status").Typed("string", "").WithEnum("available", "sold"),
+						},
+					}},
+				}},
+			}},
+			Definitions: spec.Definitions{
+				"model.Pet": {SchemaProps: spec.SchemaProps{
+					Type:        []string{"object"},
+					Description: "Pet is a pet",
+					Required:    []string{"name"},
+					Properties: spec.SchemaProperties{
+						"name": *spec.StringProperty().WithExample("rex"),
+						"tags": *spec.ArrayProperty(spec.RefSchema("#/definitions/model.Tag")),
+					},
+				}},
+				"model.Tag": {SchemaProps: spec.SchemaProps{
+					Type:       []string{"object"},
+					Properties: spec.SchemaProperties{"id": *spec.Int64Property().WithExample(1)},
+				}},
+			},
+		},
+	}
+
+	md := string(newMarkdownWriter(swagger).write())
+
+	assert.Contains(t, md, "### pets\n\nPet operations\n\n#### `POST /pets` - Create a pet")
+	assert.Contains(t, md, "Operation ID: `createPet`")
+	assert.Contains(t, md, "| pet | body | [model.Pet](#definitions-model-pet) | true |  |")
+	assert.Contains(t, md, "### default\n\n#### `GET /pets` - List pets")
+	assert.Contains(t, md, "| status | query | string | false | Enum: `available`, `sold` |")
+	assert.Contains(t, md, "| tags | [][model.Tag](#definitions-model-tag) | false |  |")
+	assert.Contains(t, md, "Example response 201:\n\n```json\n{\n  \"name\": \"rex\",\n  \"tags\": [\n    {\n      \"id\": 1\n    }\n  ]\n}\n```")
+	assert.True(t, strings.HasSuffix(md, "```\n"))
+}
+
+func TestGen_ClientOutputType(t *testing.T) {
+	config := &Config{
+		SearchDir:          searchDir,
+		MainAPIFile:        "./main.go",
+		OutputDir:          "../testdata/simple/docs",
+		OutputTypes:        []string{"client"},
+		PropNamingStrategy: "",
+	}
+	assert.NoError(t, New().Build(config))
+
+	clientDir := filepath.Join(config.OutputDir, "client")
+	t.Cleanup(func() {
+		_ = os.RemoveAll(clientDir)
+	})
+
+	b, err := os.ReadFile(filepath.Join(clientDir, "client.go"))
+	require.NoError(t, err)
+
+	src := string(b)
+	assert.Contains(t, src, "package client")
+	assert.Contains(t, src, `web "github.com/swaggo/swag/testdata/simple/web"`)
+	assert.Contains(t, src, "func (c *Client) GetStringByInt(ctx context.Context, params GetStringByIntParams) (*string, error) {")
+	assert.Contains(t, src, "Body *web.Pet")
+	assert.Contains(t, src, "func (c *Client) GetPet2(ctx context.Context) (*web.Pet2, error) {")
+	assert.Contains(t, src, "func (c *Client) GetGetPet6FunctionScopedResponse(ctx context.Context) (*json.RawMessage, error) {")
+
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = clientDir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
+func TestGen_clientIdentifier(t *testing.T) {
+	assert.Equal(t, "GetStringByInt", clientIdentifier("get-string-by-int", true))
+	assert.Equal(t, "GetUsersID", clientIdentifier("get /users/{id}", true))
+	assert.Equal(t, "fileUpload", clientIdentifier("file.upload", false))
+	assert.Equal(t, "Op200", clientIdentifier("200", true))
+}
+
+func TestGen_TypeScriptOutputType(t *testing.T) {
+	config := &Config{
+		SearchDir:   "../testdata/enums",
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/enums/docs",
+		OutputTypes: []string{"ts"},
+	}
+	assert.NoError(t, New().Build(config))
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(config.OutputDir)
+	})
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.ts"))
+	require.NoError(t, err)
+
+	ts := string(b)
+	assert.Contains(t, ts, "export enum TypesDifficulty {\n\tEasy = \"easy\",\n\t/** This one also has a comment */\n\tMedium = \"medium\",")
+	assert.Contains(t, ts, "export enum TypesGenericDifficultyTypesLevel {")
+	assert.Contains(t, ts, "\tdifficulty?: TypesDifficulty;\n")
+	assert.Contains(t, ts, "\t\"types.GenericDifficulty-types_Level\": TypesGenericDifficultyTypesLevel;\n")
+	assert.Contains(t, ts, "\t\t\t\t\ttypeinpath: \"teacher\" | \"student\" | \"Other\";\n")
+}
+
+func TestGen_typeScriptWriter(t *testing.T) {
+	swagger := &spec.Swagger{
+		SwaggerProps: spec.SwaggerProps{
+			Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+				"/users/{id}": {PathItemProps: spec.PathItemProps{
+					Get: &spec.Operation{OperationProps: spec.OperationProps{
+						ID:      "getUsers",
+						Summary: "List users",
+						Parameters: []spec.Parameter{
+							{ParamProps: spec.ParamProps{Name: "id", In: "path", Required: true}, SimpleSchema: spec.SimpleSchema{Type: "integer"}},
+							{ParamProps: spec.ParamProps{Name: "sort", In: "query"}, SimpleSchema: spec.SimpleSchema{Type: "string"}},
+						},
+						Responses: &spec.Responses{ResponsesProps: spec.ResponsesProps{StatusCodeResponses: map[int]spec.Response{
+							200: {ResponseProps: spec.ResponseProps{Description: "OK", Schema: spec.RefSchema("#/definitions/response.Page-model_User")}},
+							404: {ResponseProps: spec.ResponseProps{Description: "Not Found"}},
+						}}},
+					}},
+				}},
+			}},
+			Definitions: spec.Definitions{
+				"model.User": {SchemaProps: spec.SchemaProps{
+					Type:     spec.StringOrArray{"object"},
+					Required: []string{"name"},
+					Properties: spec.SchemaProperties{
+						"id":       {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"integer"}}, SwaggerSchemaProps: spec.SwaggerSchemaProps{ReadOnly: true}},
+						"name":     {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}},
+						"nickname": {SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{"string"}}, VendorExtensible: spec.VendorExtensible{Extensions: spec.Extensions{"x-nullable": true}}},
+					},
+				}},
+				"response.Page-model_User": {SchemaProps: spec.SchemaProps{
+					Type: spec.StringOrArray{"object"},
+					Properties: spec.SchemaProperties{
+						"items": *spec.ArrayProperty(spec.RefSchema("#/definitions/model.User")),
+					},
+				}},
+			},
+		},
+	}
+
+	ts := string(newTypeScriptWriter(swagger, nil).write())
+	assert.Contains(t, ts, "/** model.User */\nexport interface ModelUser {\n\treadonly id?: number;\n\tname: string;\n\tnickname?: string | null;\n}\n")
+	assert.Contains(t, ts, "export interface ResponsePageModelUser {\n\titems?: ModelUser[];\n}\n")
+	assert.Contains(t, ts, "\t\"/users/{id}\": {\n\t\t/** List users */\n\t\tget: {\n\t\t\tparameters: {\n\t\t\t\tpath: {\n\t\t\t\t\tid: number;\n\t\t\t\t};\n\t\t\t\tquery?: {\n\t\t\t\t\tsort?: string;\n\t\t\t\t};\n\t\t\t};\n")
+	assert.Contains(t, ts, "\t\t\t\t/** OK */\n\t\t\t\t200: ResponsePageModelUser;\n\t\t\t\t/** Not Found */\n\t\t\t\t404: void;\n")
+	assert.Contains(t, ts, "export interface Operations {\n\tgetUsers: Paths[\"/users/{id}\"][\"get\"];\n}\n")
+	assert.Equal(t, "ResponsePageModelUser", typeScriptIdentifier("response.Page-model_User"))
+}
+
+func TestGen_BuildDocuments(t *testing.T) {
+	config := &Config{
+		SearchDir:   "../testdata/split",
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/split/docs",
+		OutputTypes: []string{"go", "json"},
+		Documents: []Document{
+			{InstanceName: "public", DocumentSelector: swag.DocumentSelector{Tags: []string{"!admin", "!internal"}}},
+			{InstanceName: "admin", DocumentSelector: swag.DocumentSelector{PathPrefixes: []string{"/admin"}}},
+		},
+	}
+	require.NoError(t, New().Build(config))
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(config.OutputDir)
+	})
+
+	expectedFiles := []string{"public_docs.go", "public_swagger.json", "admin_docs.go", "admin_swagger.json"}
+	for _, expectedFile := range expectedFiles {
+		assert.FileExists(t, filepath.Join(config.OutputDir, expectedFile))
+	}
+
+	assert.NoFileExists(t, filepath.Join(config.OutputDir, "swagger.json"))
+
+	read := func(name string) *spec.Swagger {
+		b, err := os.ReadFile(filepath.Join(config.OutputDir, name))
+		require.NoError(t, err)
+
+		var swagger spec.Swagger
+		require.NoError(t, json.Unmarshal(b, &swagger))
+
+		return &swagger
+	}
+
+	public := read("public_swagger.json")
+	assert.Len(t, public.Paths.Paths, 1)
+	assert.Contains(t, public.Paths.Paths, "/v1/users")
+	assert.Len(t, public.Definitions, 3)
+	assert.NotContains(t, public.Definitions, "model.AuditEntry")
+
+	admin := read("admin_swagger.json")
+	assert.Len(t, admin.Paths.Paths, 2)
+	assert.Contains(t, admin.Definitions, "model.AuditEntry")
+	assert.NotContains(t, admin.Definitions, "model.UserPage")
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "admin_docs.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "var SwaggerInfoadmin = &swag.Spec{")
+
+	config.Documents = append(config.Documents, Document{InstanceName: "public"})
+	assert.EqualError(t, New().Build(config), "document 'public' is declared twice")
+
+	config.Documents = []Document{{}}
+	assert.EqualError(t, New().Build(config), "documents must have an instance name")
+}
+
+func TestGen_KeepUnusedDefinitions(t *testing.T) {
+	config := &Config{
+		SearchDir:   "../testdata/state",
+		MainAPIFile: "./main.go",
+		OutputDir:   "../testdata/state/docs",
+		OutputTypes: []string{"json"},
+		State:       "admin",
+	}
+
+	t.Cleanup(func() {
+		_ = os.Remove(filepath.Join(config.OutputDir, "admin_swagger.json"))
+	})
+
+	var logs bytes.Buffer
+	config.Debugger = log.New(&logs, "", 0)
+
+	require.NoError(t, New().Build(config))
+	assert.Contains(t, logs.String(), "Dropped unused definition web.RevValue (github.com/swaggo/swag/testdata/state/web.RevValue)\n")
+
+	config.KeepUnusedDefinitions = true
+	require.NoError(t, New().Build(config))
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "admin_swagger.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"web.RevValue": {`)
+}
+
+func TestGen_DefinitionName(t *testing.T) {
+	config := &Config{
+		SearchDir:      "../testdata/definition_names",
+		MainAPIFile:    "./main.go",
+		OutputDir:      "../testdata/definition_names/docs",
+		OutputTypes:    []string{"json"},
+		DefinitionName: "{{.Type}}{{.TypeArgs}}",
+	}
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(config.OutputDir)
+	})
+
+	var logs bytes.Buffer
+	config.Debugger = log.New(&logs, "", 0)
+
+	require.NoError(t, New().Build(config))
+	assert.Contains(t, logs.String(), "Named definition github.com/swaggo/swag/testdata/definition_names/v1/model.User V1ModelUser instead of User, shared with other types\n")
+
+	b, err := os.ReadFile(filepath.Join(config.OutputDir, "swagger.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"$ref": "#/definitions/PageUser"`)
+	assert.Contains(t, string(b), `"V2ModelUser": {`)
+
+	config.DefinitionName = "{{.Type"
+	assert.Error(t, New().Build(config))
+}
+
+func TestGen_BuildDiagnosticsFormat(t *testing.T) {
+	var buf bytes.Buffer
+
+	config := &Config{
+		SearchDir:         "../testdata/diagnostics",
+		MainAPIFile:       "./main.go",
+		OutputDir:         "../testdata/diagnostics/docs",
+		OutputTypes:       []string{"json"},
+		DiagnosticsFormat: swag.GitHubDiagnostics,
+		DiagnosticsOutput: &buf,
+	}
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(config.OutputDir)
+	})
+
+	assert.EqualError(t, New().Build(config), "2 errors found")
+	assert.Equal(t, "::warning file=../testdata/diagnostics/api/api.go,line=13,col=1,title=unknown-attribute::unknown attribute @Sucess, did you mean @Success?\n"+
+		"::error file=../testdata/diagnostics/api/api.go,line=21,col=1,title=invalid-comment::cannot find type definition: api.Member\n"+
+		"::error file=../testdata/diagnostics/api/api.go,line=28,col=1,title=invalid-comment::invalid method: REMOVE\n", buf.String())
+
+	// without a format, the errors are returned as they are
+	config.DiagnosticsFormat = ""
+	err := New().Build(config)
+
+	var diagnostics swag.Diagnostics
+	require.True(t, errors.As(err, &diagnostics))
+	assert.Len(t, diagnostics, 2)
+}
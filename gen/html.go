@@ -0,0 +1,84 @@
+package gen
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// redocBundleURL is the Redoc bundle rendering the page, pinned to a version so that the
+// page renders the same until swag updates it.
+const redocBundleURL = "https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js"
+
+//go:generate curl -sSfL -o redoc.standalone.js https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js
+
+// htmlTemplate renders a single page which embeds the swagger document, so the page
+// can be published as a static artifact next to swagger.json and swagger.yaml. The page
+// inlines the Redoc bundle when swag is built with the redoc tag, and loads it from its
+// CDN otherwise.
+var htmlTemplate = template.Must(template.New("swagger_html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1">
+    <title>{{ .Title }}</title>
+    <style>
+        body {
+            margin: 0;
+            padding: 0;
+        }
+    </style>
+</head>
+<body>
+<div id="redoc-container"></div>
+{{ if .InlineBundle }}<script>{{ .InlineBundle }}</script>
+{{ else }}<script src="{{ .Bundle }}" crossorigin="anonymous"></script>
+{{ end }}<script>
+    Redoc.init({{ .Spec }}, {}, document.getElementById("redoc-container"));
+</script>
+</body>
+</html>
+`))
+
+func (g *Gen) writeHTMLSwagger(config *Config, swagger *spec.Swagger) error {
+	htmlFileName := OutputFileName(config, "swagger.html")
+
+	b, err := g.json(swagger)
+	if err != nil {
+		return err
+	}
+
+	var title string
+	if swagger.Info != nil {
+		title = swagger.Info.Title
+	}
+
+	var buffer bytes.Buffer
+
+	err = htmlTemplate.Execute(&buffer, struct {
+		Title        string
+		Bundle       string
+		InlineBundle template.JS
+		Spec         template.JS
+	}{
+		Title:  title,
+		Bundle: redocBundleURL,
+		// the bundle cannot close the script element it is inlined in
+		InlineBundle: template.JS(strings.ReplaceAll(redocBundle, "</script", `<\/script`)),
+		Spec:         template.JS(b),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = g.writeFile(buffer.Bytes(), htmlFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create swagger.html at %+v", htmlFileName)
+
+	return nil
+}
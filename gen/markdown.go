@@ -0,0 +1,532 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// defaultMarkdownTag groups operations which have no tags.
+const defaultMarkdownTag = "default"
+
+// maxMarkdownExampleDepth limits how deep examples are composed from nested definitions.
+const maxMarkdownExampleDepth = 5
+
+func (g *Gen) writeMarkdownSwagger(config *Config, swagger *spec.Swagger) error {
+	mdFileName := OutputFileName(config, "swagger.md")
+
+	err := g.writeFile(newMarkdownWriter(swagger).write(), mdFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create swagger.md at %+v", mdFileName)
+
+	return nil
+}
+
+type markdownOperation struct {
+	method    string
+	path      string
+	operation *spec.Operation
+}
+
+// markdownWriter renders a swagger document as a Markdown API reference.
+type markdownWriter struct {
+	swagger *spec.Swagger
+	buffer  bytes.Buffer
+}
+
+func newMarkdownWriter(swagger *spec.Swagger) *markdownWriter {
+	return &markdownWriter{swagger: swagger}
+}
+
+func (w *markdownWriter) write() []byte {
+	w.writeInfo()
+	w.writeOperations()
+	w.writeDefinitions()
+
+	return append(bytes.TrimRight(w.buffer.Bytes(), "\n"), '\n')
+}
+
+func (w *markdownWriter) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&w.buffer, format, args...)
+}
+
+func (w *markdownWriter) writeInfo() {
+	title := "API Reference"
+	if w.swagger.Info != nil && w.swagger.Info.Title != "" {
+		title = w.swagger.Info.Title
+	}
+
+	w.printf("# %s\n\n", title)
+
+	if w.swagger.Info != nil {
+		if w.swagger.Info.Version != "" {
+			w.printf("Version: %s\n\n", w.swagger.Info.Version)
+		}
+
+		if w.swagger.Info.Description != "" {
+			w.printf("%s\n\n", w.swagger.Info.Description)
+		}
+	}
+
+	if w.swagger.Host != "" || w.swagger.BasePath != "" {
+		w.printf("Base URL: `%s%s`\n\n", w.swagger.Host, w.swagger.BasePath)
+	}
+}
+
+// groupOperations groups operations by tag. Declared tags come first in declaration
+// order, followed by the remaining tags in alphabetical order.
+func (w *markdownWriter) groupOperations() ([]string, map[string][]markdownOperation) {
+	groups := make(map[string][]markdownOperation)
+
+	if w.swagger.Paths != nil {
+		paths := make([]string, 0, len(w.swagger.Paths.Paths))
+		for path := range w.swagger.Paths.Paths {
+			paths = append(paths, path)
+		}
+
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			item := w.swagger.Paths.Paths[path]
+			for _, method := range operationMethods {
+				operation := swag.PathItemOperation(&item, method)
+				if operation == nil {
+					continue
+				}
+
+				tags := operation.Tags
+				if len(tags) == 0 {
+					tags = []string{defaultMarkdownTag}
+				}
+
+				for _, tag := range tags {
+					groups[tag] = append(groups[tag], markdownOperation{method: method, path: path, operation: operation})
+				}
+			}
+		}
+	}
+
+	var (
+		names    []string
+		declared = make(map[string]bool)
+	)
+
+	for _, tag := range w.swagger.Tags {
+		if _, ok := groups[tag.Name]; ok && !declared[tag.Name] {
+			names = append(names, tag.Name)
+			declared[tag.Name] = true
+		}
+	}
+
+	var rest []string
+
+	for tag := range groups {
+		if !declared[tag] {
+			rest = append(rest, tag)
+		}
+	}
+
+	sort.Strings(rest)
+
+	return append(names, rest...), groups
+}
+
+func (w *markdownWriter) tagDescription(name string) string {
+	for _, tag := range w.swagger.Tags {
+		if tag.Name == name {
+			return tag.Description
+		}
+	}
+
+	return ""
+}
+
+func (w *markdownWriter) writeOperations() {
+	tags, groups := w.groupOperations()
+	if len(tags) == 0 {
+		return
+	}
+
+	w.printf("## Operations\n\n")
+
+	for _, tag := range tags {
+		w.printf("### %s\n\n", tag)
+
+		if description := w.tagDescription(tag); description != "" {
+			w.printf("%s\n\n", description)
+		}
+
+		for _, op := range groups[tag] {
+			w.writeOperation(op)
+		}
+	}
+}
+
+func (w *markdownWriter) writeOperation(op markdownOperation) {
+	operation := op.operation
+
+	heading := fmt.Sprintf("`%s %s`", op.method, op.path)
+	if operation.Summary != "" {
+		heading += " - " + operation.Summary
+	}
+
+	w.printf("#### %s\n\n", heading)
+
+	if operation.Deprecated {
+		w.printf("> **Deprecated**\n\n")
+	}
+
+	if operation.ID != "" {
+		w.printf("Operation ID: `%s`\n\n", operation.ID)
+	}
+
+	if operation.Description != "" {
+		w.printf("%s\n\n", operation.Description)
+	}
+
+	if len(operation.Consumes) > 0 {
+		w.printf("Accept: `%s`\n\n", strings.Join(operation.Consumes, "`, `"))
+	}
+
+	if len(operation.Produces) > 0 {
+		w.printf("Produce: `%s`\n\n", strings.Join(operation.Produces, "`, `"))
+	}
+
+	w.writeParameters(operation.Parameters)
+	w.writeResponses(operation.Responses)
+}
+
+func (w *markdownWriter) writeParameters(params []spec.Parameter) {
+	if len(params) == 0 {
+		return
+	}
+
+	w.printf("**Parameters**\n\n")
+	w.printf("| Name | In | Type | Required | Description |\n")
+	w.printf("| ---- | -- | ---- | -------- | ----------- |\n")
+
+	var body *spec.Parameter
+
+	for i := range params {
+		param := resolveParameter(w.swagger, &params[i])
+
+		var typeName string
+		if param.In == "body" {
+			typeName = w.schemaType(param.Schema)
+			body = param
+		} else {
+			typeName = parameterType(param)
+		}
+
+		description := param.Description
+		if len(param.Enum) > 0 {
+			description = appendMarkdownEnum(description, param.Enum)
+		}
+
+		w.printf("| %s | %s | %s | %t | %s |\n",
+			markdownCell(param.Name), param.In, typeName, param.Required, markdownCell(description))
+	}
+
+	w.printf("\n")
+
+	if body != nil {
+		w.writeExample("Example request body", body.Schema)
+	}
+}
+
+func (w *markdownWriter) writeResponses(responses *spec.Responses) {
+	if responses == nil || (responses.Default == nil && len(responses.StatusCodeResponses) == 0) {
+		return
+	}
+
+	codes := make([]int, 0, len(responses.StatusCodeResponses))
+	for code := range responses.StatusCodeResponses {
+		codes = append(codes, code)
+	}
+
+	sort.Ints(codes)
+
+	type markdownResponse struct {
+		code     string
+		response *spec.Response
+	}
+
+	list := make([]markdownResponse, 0, len(codes)+1)
+	for _, code := range codes {
+		response := responses.StatusCodeResponses[code]
+		list = append(list, markdownResponse{code: strconv.Itoa(code), response: resolveResponse(w.swagger, &response)})
+	}
+
+	if responses.Default != nil {
+		list = append(list, markdownResponse{code: defaultMarkdownTag, response: resolveResponse(w.swagger, responses.Default)})
+	}
+
+	w.printf("**Responses**\n\n")
+	w.printf("| Code | Description | Schema |\n")
+	w.printf("| ---- | ----------- | ------ |\n")
+
+	for _, item := range list {
+		schema := ""
+		if item.response.Schema != nil {
+			schema = w.schemaType(item.response.Schema)
+		}
+
+		w.printf("| %s | %s | %s |\n", item.code, markdownCell(item.response.Description), schema)
+	}
+
+	w.printf("\n")
+
+	for _, item := range list {
+		w.writeExample("Example response "+item.code, item.response.Schema)
+	}
+}
+
+func (w *markdownWriter) writeExample(title string, schema *spec.Schema) {
+	if schema == nil {
+		return
+	}
+
+	example := w.example(schema, 0)
+	if example == nil {
+		return
+	}
+
+	b, err := json.MarshalIndent(example, "", "  ")
+	if err != nil {
+		return
+	}
+
+	w.printf("%s:\n\n```json\n%s\n```\n\n", title, b)
+}
+
+func (w *markdownWriter) writeDefinitions() {
+	if len(w.swagger.Definitions) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(w.swagger.Definitions))
+	for name := range w.swagger.Definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	w.printf("## Definitions\n\n")
+
+	for _, name := range names {
+		definition := w.swagger.Definitions[name]
+
+		w.printf("<a id=\"%s\"></a>\n\n### %s\n\n", markdownAnchor(name), name)
+
+		if definition.Description != "" {
+			w.printf("%s\n\n", definition.Description)
+		}
+
+		if len(definition.Properties) == 0 {
+			w.printf("Type: %s\n\n", w.schemaType(&definition))
+
+			if len(definition.Enum) > 0 {
+				w.printf("%s\n\n", appendMarkdownEnum("", definition.Enum))
+			}
+
+			continue
+		}
+
+		required := make(map[string]bool, len(definition.Required))
+		for _, field := range definition.Required {
+			required[field] = true
+		}
+
+		w.printf("| Field | Type | Required | Description |\n")
+		w.printf("| ----- | ---- | -------- | ----------- |\n")
+
+		for _, item := range definition.Properties.ToOrderedSchemaItems() {
+			description := item.Schema.Description
+			if len(item.Schema.Enum) > 0 {
+				description = appendMarkdownEnum(description, item.Schema.Enum)
+			}
+
+			w.printf("| %s | %s | %t | %s |\n",
+				markdownCell(item.Name), w.schemaType(&item.Schema), required[item.Name], markdownCell(description))
+		}
+
+		w.printf("\n")
+
+		w.writeExample("Example", &definition)
+	}
+}
+
+// schemaType renders the type of schema, linking referenced definitions.
+func (w *markdownWriter) schemaType(schema *spec.Schema) string {
+	if schema == nil {
+		return ""
+	}
+
+	if name := definitionName(schema); name != "" {
+		return fmt.Sprintf("[%s](#%s)", name, markdownAnchor(name))
+	}
+
+	if len(schema.AllOf) > 0 {
+		types := make([]string, 0, len(schema.AllOf))
+		for i := range schema.AllOf {
+			types = append(types, w.schemaType(&schema.AllOf[i]))
+		}
+
+		return strings.Join(types, " & ")
+	}
+
+	if len(schema.Type) == 0 {
+		return "any"
+	}
+
+	switch schema.Type[0] {
+	case "array":
+		if schema.Items != nil && schema.Items.Schema != nil {
+			return "[]" + w.schemaType(schema.Items.Schema)
+		}
+
+		return "[]any"
+	case "object":
+		if schema.AdditionalProperties != nil {
+			if schema.AdditionalProperties.Schema != nil {
+				return "map[string]" + w.schemaType(schema.AdditionalProperties.Schema)
+			}
+
+			return "map[string]any"
+		}
+	}
+
+	if schema.Format != "" {
+		return fmt.Sprintf("%s (%s)", schema.Type[0], schema.Format)
+	}
+
+	return schema.Type[0]
+}
+
+// example composes an example value for schema from schema.Example, or from the
+// examples of its properties and items.
+func (w *markdownWriter) example(schema *spec.Schema, depth int) any {
+	if schema == nil || depth > maxMarkdownExampleDepth {
+		return nil
+	}
+
+	if schema.Example != nil {
+		return schema.Example
+	}
+
+	if name := definitionName(schema); name != "" {
+		definition, ok := w.swagger.Definitions[name]
+		if !ok {
+			return nil
+		}
+
+		return w.example(&definition, depth+1)
+	}
+
+	if len(schema.AllOf) > 0 {
+		merged := make(map[string]any)
+		for i := range schema.AllOf {
+			if value, ok := w.example(&schema.AllOf[i], depth+1).(map[string]any); ok {
+				for k, v := range value {
+					merged[k] = v
+				}
+			}
+		}
+
+		if len(merged) == 0 {
+			return nil
+		}
+
+		return merged
+	}
+
+	if len(schema.Type) == 0 {
+		return nil
+	}
+
+	switch schema.Type[0] {
+	case "array":
+		if schema.Items == nil {
+			return nil
+		}
+
+		item := w.example(schema.Items.Schema, depth+1)
+		if item == nil {
+			return nil
+		}
+
+		return []any{item}
+	case "object":
+		values := make(map[string]any)
+		for name, property := range schema.Properties {
+			if value := w.example(&property, depth+1); value != nil {
+				values[name] = value
+			}
+		}
+
+		if len(values) == 0 {
+			return nil
+		}
+
+		return values
+	}
+
+	return nil
+}
+
+func parameterType(param *spec.Parameter) string {
+	if param.Type == "array" && param.Items != nil {
+		return "[]" + param.Items.Type
+	}
+
+	if param.Format != "" {
+		return fmt.Sprintf("%s (%s)", param.Type, param.Format)
+	}
+
+	return param.Type
+}
+
+func appendMarkdownEnum(description string, enum []any) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		values = append(values, fmt.Sprintf("`%v`", value))
+	}
+
+	enumText := "Enum: " + strings.Join(values, ", ")
+	if description == "" {
+		return enumText
+	}
+
+	return description + "\n" + enumText
+}
+
+// markdownAnchor returns the anchor id of a definition section.
+func markdownAnchor(name string) string {
+	return "definitions-" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+
+		return '-'
+	}, name)
+}
+
+// markdownCell escapes text to be placed in a table cell.
+func markdownCell(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	return strings.ReplaceAll(strings.TrimSpace(text), "\n", "<br>")
+}
@@ -0,0 +1,94 @@
+package gen
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// Project locates the Go files of an API and configures how they are parsed, for the
+// commands parsing an API without generating its documents like swag lint and stats.
+type Project struct {
+	// SearchDir the swag would parse,comma separated if multiple
+	SearchDir string
+
+	// excludes dirs and files in SearchDir,comma separated
+	Excludes string
+
+	// MainAPIFile the Go file path in which 'swagger general API Info' is written
+	MainAPIFile string
+
+	// MarkdownFilesDir used to find markdown files, which can be used for tag descriptions
+	MarkdownFilesDir string
+
+	// CodeExampleFilesDir used to find code example files, which can be used for x-codeSamples
+	CodeExampleFilesDir string
+
+	// ParseDepth dependency parse depth
+	ParseDepth int
+
+	// ParseVendor whether swag should be parse vendor folder
+	ParseVendor bool
+
+	// ParseDependencies whether swag should be parse outside dependency folder: 0 none, 1 models, 2 operations, 3 all
+	ParseDependency int
+
+	// ParseInternal whether swag should parse internal packages
+	ParseInternal bool
+
+	// OverridesFile defines global type overrides.
+	OverridesFile string
+
+	// ParseGoList whether swag use go list to parse dependency
+	ParseGoList bool
+
+	// include only tags mentioned when searching, comma separated
+	Tags string
+}
+
+// Parse parses the API of the project with a parser configured by the project and then
+// by options. The parser is returned along with the error of the parsing, holding what
+// was parsed, and is nil only if it could not be created.
+func (project *Project) Parse(options ...func(*swag.Parser)) (*swag.Parser, error) {
+	overrides, err := readOverrides(project.OverridesFile)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := swag.New(append([]func(*swag.Parser){
+		swag.SetParseDependency(project.ParseDependency),
+		swag.SetMarkdownFileDirectory(project.MarkdownFilesDir),
+		swag.SetExcludedDirsAndFiles(project.Excludes),
+		swag.SetCodeExamplesDirectory(project.CodeExampleFilesDir),
+		swag.SetOverrides(overrides),
+		swag.ParseUsingGoList(project.ParseGoList),
+		swag.SetTags(project.Tags),
+	}, options...)...)
+
+	parser.ParseVendor = project.ParseVendor
+	parser.ParseInternal = project.ParseInternal
+
+	return parser, parser.ParseAPIMultiSearchDir(strings.Split(project.SearchDir, ","), project.MainAPIFile, project.ParseDepth)
+}
+
+// readOverrides reads the global type overrides of the file, none if it is empty or if it
+// is the default one and is missing.
+func readOverrides(path string) (map[string]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	overridesFile, err := open(path)
+	if err != nil {
+		// Don't bother reporting if the default file is missing; assume there are no overrides
+		if path == DefaultOverridesFile && os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("could not open overrides file: %w", err)
+	}
+
+	return parseOverrides(overridesFile)
+}
@@ -0,0 +1,7 @@
+//go:build !redoc
+
+package gen
+
+// redocBundle is the Redoc bundle inlined in the HTML page, none unless swag is built with
+// the redoc tag, so the page loads the bundle from its CDN.
+var redocBundle string
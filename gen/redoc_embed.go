@@ -0,0 +1,11 @@
+//go:build redoc
+
+package gen
+
+import _ "embed" // for the Redoc bundle
+
+// redocBundle is the Redoc bundle inlined in the HTML page, so that the page renders
+// offline. The bundle of redocBundleURL is fetched by go generate.
+//
+//go:embed redoc.standalone.js
+var redocBundle string
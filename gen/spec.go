@@ -0,0 +1,62 @@
+package gen
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// operationMethods are the methods of the operations of a path item, in the order the
+// output types write them in.
+var operationMethods = []string{
+	http.MethodGet,
+	http.MethodPut,
+	http.MethodPost,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodHead,
+	http.MethodPatch,
+}
+
+// resolveParameter returns the parameter of swagger param refers to, or param itself.
+func resolveParameter(swagger *spec.Swagger, param *spec.Parameter) *spec.Parameter {
+	name := refName(param.Ref, "#/parameters/")
+	if name == "" {
+		return param
+	}
+
+	if resolved, ok := swagger.Parameters[name]; ok {
+		return &resolved
+	}
+
+	return param
+}
+
+// resolveResponse returns the response of swagger response refers to, or response itself.
+func resolveResponse(swagger *spec.Swagger, response *spec.Response) *spec.Response {
+	name := refName(response.Ref, "#/responses/")
+	if name == "" {
+		return response
+	}
+
+	if resolved, ok := swagger.Responses[name]; ok {
+		return &resolved
+	}
+
+	return response
+}
+
+// definitionName returns the name of the definition referenced by schema, if any.
+func definitionName(schema *spec.Schema) string {
+	return refName(schema.Ref, "#/definitions/")
+}
+
+func refName(ref spec.Ref, prefix string) string {
+	str := ref.String()
+	if !strings.HasPrefix(str, prefix) {
+		return ""
+	}
+
+	return str[len(prefix):]
+}
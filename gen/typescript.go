@@ -0,0 +1,678 @@
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+func (g *Gen) writeTypeScriptSwagger(config *Config, swagger *spec.Swagger) error {
+	tsFileName := OutputFileName(config, "swagger.ts")
+
+	err := g.writeFile(newTypeScriptWriter(swagger, g.definitionTypes).write(), tsFileName)
+	if err != nil {
+		return err
+	}
+
+	g.debug.Printf("create swagger.ts at %+v", tsFileName)
+
+	return nil
+}
+
+// typeScriptWriter renders TypeScript declarations for the definitions and operations
+// of a swagger document.
+type typeScriptWriter struct {
+	swagger         *spec.Swagger
+	definitionTypes map[string]*swag.TypeSpecDef
+
+	// names maps definition names to the TypeScript identifiers they are declared as.
+	names map[string]string
+
+	buffer bytes.Buffer
+}
+
+func newTypeScriptWriter(swagger *spec.Swagger, definitionTypes map[string]*swag.TypeSpecDef) *typeScriptWriter {
+	w := &typeScriptWriter{
+		swagger:         swagger,
+		definitionTypes: definitionTypes,
+		names:           make(map[string]string),
+	}
+
+	used := make(map[string]bool)
+	for _, name := range w.definitionNames() {
+		identifier := typeScriptIdentifier(name)
+		for i := 2; used[identifier]; i++ {
+			identifier = typeScriptIdentifier(name) + strconv.Itoa(i)
+		}
+
+		used[identifier] = true
+		w.names[name] = identifier
+	}
+
+	return w
+}
+
+func (w *typeScriptWriter) printf(format string, args ...any) {
+	_, _ = fmt.Fprintf(&w.buffer, format, args...)
+}
+
+func (w *typeScriptWriter) write() []byte {
+	w.printf("// Code generated by swaggo/swag. DO NOT EDIT.\n")
+	w.printf("/* eslint-disable */\n")
+
+	w.writeDefinitions()
+	w.writePaths()
+
+	return w.buffer.Bytes()
+}
+
+func (w *typeScriptWriter) definitionNames() []string {
+	names := make([]string, 0, len(w.swagger.Definitions))
+	for name := range w.swagger.Definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (w *typeScriptWriter) writeDefinitions() {
+	names := w.definitionNames()
+
+	for _, name := range names {
+		definition := w.swagger.Definitions[name]
+		identifier := w.names[name]
+
+		w.printf("\n")
+		w.writeComment("", name, definition.Title, definition.Description)
+
+		switch {
+		case len(definition.Enum) > 0 && len(stringSlice(definition.Extensions["x-enum-varnames"])) == len(definition.Enum):
+			w.writeEnum(identifier, &definition)
+		case isTypeScriptInterface(&definition):
+			w.printf("export interface %s ", identifier)
+
+			if len(definition.AllOf) > 0 {
+				parents := make([]string, 0, len(definition.AllOf))
+				for i := range definition.AllOf {
+					parents = append(parents, w.schemaType(&definition.AllOf[i], "", 0))
+				}
+
+				w.printf("extends %s ", strings.Join(parents, ", "))
+			}
+
+			w.printf("%s\n", w.objectType(&definition, w.nullableFields(name), ""))
+		default:
+			w.printf("export type %s = %s;\n", identifier, w.schemaType(&definition, "", 0))
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	w.printf("\n/** Definitions maps the definition names of the document to their declarations. */\n")
+	w.printf("export interface Definitions {\n")
+
+	for _, name := range names {
+		w.printf("\t%s: %s;\n", typeScriptProperty(name), w.names[name])
+	}
+
+	w.printf("}\n")
+}
+
+func (w *typeScriptWriter) writeEnum(identifier string, definition *spec.Schema) {
+	varNames := stringSlice(definition.Extensions["x-enum-varnames"])
+	descriptions := stringSlice(definition.Extensions["x-enum-descriptions"])
+
+	w.printf("export enum %s {\n", identifier)
+
+	for i, value := range definition.Enum {
+		if i < len(descriptions) && descriptions[i] != "" {
+			w.printf("\t/** %s */\n", typeScriptCommentText(descriptions[i]))
+		}
+
+		w.printf("\t%s = %s,\n", typeScriptProperty(varNames[i]), typeScriptLiteral(value))
+	}
+
+	w.printf("}\n")
+}
+
+func (w *typeScriptWriter) writePaths() {
+	if w.swagger.Paths == nil || len(w.swagger.Paths.Paths) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(w.swagger.Paths.Paths))
+	for path := range w.swagger.Paths.Paths {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	type operationID struct {
+		id, path, method string
+	}
+
+	var operationIDs []operationID
+
+	w.printf("\n/** Paths describes the parameters and responses of every operation by path and method. */\n")
+	w.printf("export interface Paths {\n")
+
+	for _, path := range paths {
+		item := w.swagger.Paths.Paths[path]
+
+		w.printf("\t%s: {\n", strconv.Quote(path))
+
+		for _, method := range operationMethods {
+			operation := swag.PathItemOperation(&item, method)
+			if operation == nil {
+				continue
+			}
+
+			method = strings.ToLower(method)
+			if operation.ID != "" {
+				operationIDs = append(operationIDs, operationID{id: operation.ID, path: path, method: method})
+			}
+
+			description := operation.Description
+			if description == operation.Summary {
+				description = ""
+			}
+
+			w.writeComment("\t\t", operation.Summary, description)
+			w.printf("\t\t%s: {\n", method)
+			w.writeParameters(append(append([]spec.Parameter{}, item.Parameters...), operation.Parameters...))
+			w.writeResponses(operation.Responses)
+			w.printf("\t\t};\n")
+		}
+
+		w.printf("\t};\n")
+	}
+
+	w.printf("}\n")
+
+	if len(operationIDs) == 0 {
+		return
+	}
+
+	sort.Slice(operationIDs, func(i, j int) bool {
+		return operationIDs[i].id < operationIDs[j].id
+	})
+
+	w.printf("\n/** Operations maps operation ids to their entries in Paths. */\n")
+	w.printf("export interface Operations {\n")
+
+	for _, op := range operationIDs {
+		w.printf("\t%s: Paths[%s][%s];\n", typeScriptProperty(op.id), strconv.Quote(op.path), strconv.Quote(op.method))
+	}
+
+	w.printf("}\n")
+}
+
+func (w *typeScriptWriter) writeParameters(params []spec.Parameter) {
+	groups := make(map[string][]*spec.Parameter)
+
+	for i := range params {
+		param := resolveParameter(w.swagger, &params[i])
+		groups[param.In] = append(groups[param.In], param)
+	}
+
+	if len(groups) == 0 {
+		w.printf("\t\t\tparameters: {};\n")
+
+		return
+	}
+
+	w.printf("\t\t\tparameters: {\n")
+
+	for _, in := range []string{"path", "query", "header", "formData", "body"} {
+		group := groups[in]
+		if len(group) == 0 {
+			continue
+		}
+
+		required := false
+		for _, param := range group {
+			required = required || param.Required
+		}
+
+		if in == "body" {
+			w.printf("\t\t\t\tbody%s: %s;\n", optionalMark(required), w.schemaType(group[0].Schema, "\t\t\t\t", 0))
+
+			continue
+		}
+
+		w.printf("\t\t\t\t%s%s: {\n", in, optionalMark(required))
+
+		for _, param := range group {
+			w.writeComment("\t\t\t\t\t", param.Description)
+			w.printf("\t\t\t\t\t%s%s: %s;\n", typeScriptProperty(param.Name), optionalMark(param.Required), w.parameterType(param))
+		}
+
+		w.printf("\t\t\t\t};\n")
+	}
+
+	w.printf("\t\t\t};\n")
+}
+
+func (w *typeScriptWriter) writeResponses(responses *spec.Responses) {
+	if responses == nil {
+		w.printf("\t\t\tresponses: {};\n")
+
+		return
+	}
+
+	codes := make([]int, 0, len(responses.StatusCodeResponses))
+	for code := range responses.StatusCodeResponses {
+		codes = append(codes, code)
+	}
+
+	sort.Ints(codes)
+
+	w.printf("\t\t\tresponses: {\n")
+
+	for _, code := range codes {
+		response := responses.StatusCodeResponses[code]
+		w.writeResponse(strconv.Itoa(code), resolveResponse(w.swagger, &response))
+	}
+
+	if responses.Default != nil {
+		w.writeResponse("default", resolveResponse(w.swagger, responses.Default))
+	}
+
+	w.printf("\t\t\t};\n")
+}
+
+func (w *typeScriptWriter) writeResponse(code string, response *spec.Response) {
+	w.writeComment("\t\t\t\t", response.Description)
+
+	if response.Schema == nil {
+		w.printf("\t\t\t\t%s: void;\n", code)
+
+		return
+	}
+
+	w.printf("\t\t\t\t%s: %s;\n", code, w.schemaType(response.Schema, "\t\t\t\t", 0))
+}
+
+// writeComment writes a doc comment made of the non-empty lines.
+func (w *typeScriptWriter) writeComment(indent string, lines ...string) {
+	var text []string
+
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			text = append(text, strings.Split(typeScriptCommentText(line), "\n")...)
+		}
+	}
+
+	switch len(text) {
+	case 0:
+		return
+	case 1:
+		w.printf("%s/** %s */\n", indent, text[0])
+
+		return
+	}
+
+	w.printf("%s/**\n", indent)
+
+	for _, line := range text {
+		w.printf("%s * %s\n", indent, strings.TrimRight(line, " \t\r"))
+	}
+
+	w.printf("%s */\n", indent)
+}
+
+// schemaType renders schema as a TypeScript type, inline objects are indented with indent.
+func (w *typeScriptWriter) schemaType(schema *spec.Schema, indent string, depth int) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	tsType := w.nonNullSchemaType(schema, indent, depth)
+	if isNullable(schema) && tsType != "unknown" && tsType != "any" {
+		return tsType + " | null"
+	}
+
+	return tsType
+}
+
+func (w *typeScriptWriter) nonNullSchemaType(schema *spec.Schema, indent string, depth int) string {
+	if name := definitionName(schema); name != "" {
+		if identifier, ok := w.names[name]; ok {
+			return identifier
+		}
+
+		return "unknown"
+	}
+
+	if len(schema.AllOf) > 0 {
+		types := make([]string, 0, len(schema.AllOf))
+		for i := range schema.AllOf {
+			types = append(types, w.schemaType(&schema.AllOf[i], indent, depth+1))
+		}
+
+		if len(types) == 1 {
+			return types[0]
+		}
+
+		return "(" + strings.Join(types, " & ") + ")"
+	}
+
+	if len(schema.Enum) > 0 {
+		return typeScriptUnion(schema.Enum)
+	}
+
+	if len(schema.Type) == 0 {
+		if len(schema.Properties) > 0 {
+			return w.objectType(schema, nil, indent)
+		}
+
+		return "unknown"
+	}
+
+	switch schema.Type[0] {
+	case "array":
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return "unknown[]"
+		}
+
+		item := w.schemaType(schema.Items.Schema, indent, depth+1)
+		if strings.ContainsAny(item, "|&") && !strings.HasPrefix(item, "(") {
+			return "(" + item + ")[]"
+		}
+
+		return item + "[]"
+	case "object":
+		if len(schema.Properties) > 0 {
+			return w.objectType(schema, nil, indent)
+		}
+
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "Record<string, " + w.schemaType(schema.AdditionalProperties.Schema, indent, depth+1) + ">"
+		}
+
+		return "Record<string, unknown>"
+	}
+
+	return typeScriptPrimitiveType(schema.Type[0], schema.Format)
+}
+
+// objectType renders the properties of schema as an object type literal. Properties
+// listed in nullable accept null besides the ones marked x-nullable.
+func (w *typeScriptWriter) objectType(schema *spec.Schema, nullable map[string]bool, indent string) string {
+	if len(schema.Properties) == 0 {
+		if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+			return "{\n" + indent + "\t[key: string]: " + w.schemaType(schema.AdditionalProperties.Schema, indent+"\t", 0) + ";\n" + indent + "}"
+		}
+
+		return "{}"
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var sb strings.Builder
+
+	sb.WriteString("{\n")
+
+	for _, name := range names {
+		property := schema.Properties[name]
+
+		if property.Description != "" {
+			comment := typeScriptCommentText(strings.TrimSpace(property.Description))
+			sb.WriteString(indent + "\t/** " + strings.ReplaceAll(comment, "\n", "\n"+indent+"\t * ") + " */\n")
+		}
+
+		sb.WriteString(indent + "\t")
+
+		if property.ReadOnly {
+			sb.WriteString("readonly ")
+		}
+
+		propertyType := w.schemaType(&property, indent+"\t", 0)
+		if nullable[name] && !strings.HasSuffix(propertyType, " | null") {
+			propertyType += " | null"
+		}
+
+		sb.WriteString(typeScriptProperty(name) + optionalMark(required[name]) + ": " + propertyType + ";\n")
+	}
+
+	sb.WriteString(indent + "}")
+
+	return sb.String()
+}
+
+func (w *typeScriptWriter) parameterType(param *spec.Parameter) string {
+	if len(param.Enum) > 0 {
+		return typeScriptUnion(param.Enum)
+	}
+
+	if param.Type == "array" {
+		if param.Items == nil {
+			return "unknown[]"
+		}
+
+		if len(param.Items.Enum) > 0 {
+			return "(" + typeScriptUnion(param.Items.Enum) + ")[]"
+		}
+
+		return typeScriptPrimitiveType(param.Items.Type, param.Items.Format) + "[]"
+	}
+
+	return typeScriptPrimitiveType(param.Type, param.Format)
+}
+
+// nullableFields returns the json names of the pointer fields of the struct the
+// definition was generated from.
+func (w *typeScriptWriter) nullableFields(name string) map[string]bool {
+	typeSpecDef, ok := w.definitionTypes[name]
+	if !ok || typeSpecDef.TypeSpec == nil {
+		return nil
+	}
+
+	structType, ok := typeSpecDef.TypeSpec.Type.(*ast.StructType)
+	if !ok || structType.Fields == nil {
+		return nil
+	}
+
+	definition := w.swagger.Definitions[name]
+
+	properties := make(map[string]string, len(definition.Properties))
+	for property := range definition.Properties {
+		properties[typeScriptFieldKey(property)] = property
+	}
+
+	nullable := make(map[string]bool)
+
+	for _, field := range structType.Fields.List {
+		if _, ok := field.Type.(*ast.StarExpr); !ok {
+			continue
+		}
+
+		for _, fieldName := range field.Names {
+			key := fieldName.Name
+
+			if field.Tag != nil {
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if err == nil {
+					jsonName := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+					if jsonName == "-" {
+						continue
+					}
+
+					if jsonName != "" {
+						key = jsonName
+					}
+				}
+			}
+
+			if property, ok := properties[typeScriptFieldKey(key)]; ok {
+				nullable[property] = true
+			}
+		}
+	}
+
+	return nullable
+}
+
+// isTypeScriptInterface reports whether definition is declared as an interface
+// rather than a type alias.
+func isTypeScriptInterface(definition *spec.Schema) bool {
+	if isNullable(definition) || len(definition.Enum) > 0 {
+		return false
+	}
+
+	for i := range definition.AllOf {
+		if definitionName(&definition.AllOf[i]) == "" {
+			return false
+		}
+	}
+
+	if len(definition.Properties) > 0 {
+		return true
+	}
+
+	return len(definition.AllOf) == 0 && definition.Type.Contains("object") &&
+		definition.AdditionalProperties != nil && definition.AdditionalProperties.Schema != nil
+}
+
+func isNullable(schema *spec.Schema) bool {
+	if schema.Nullable {
+		return true
+	}
+
+	nullable, _ := schema.Extensions["x-nullable"].(bool)
+
+	return nullable
+}
+
+func typeScriptPrimitiveType(schemaType, format string) string {
+	switch schemaType {
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "string":
+		if format == "binary" {
+			return "Blob"
+		}
+
+		return "string"
+	case "file":
+		return "Blob"
+	}
+
+	return "unknown"
+}
+
+// typeScriptIdentifier converts a definition name into a TypeScript type name,
+// e.g. response.Page-model_User => ResponsePageModelUser.
+func typeScriptIdentifier(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var sb strings.Builder
+
+	for _, part := range parts {
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		sb.WriteString(string(runes))
+	}
+
+	identifier := sb.String()
+	if identifier == "" || unicode.IsDigit([]rune(identifier)[0]) {
+		identifier = "T" + identifier
+	}
+
+	return identifier
+}
+
+// typeScriptProperty quotes name unless it is a valid property identifier.
+func typeScriptProperty(name string) string {
+	for i, r := range name {
+		if r != '_' && r != '$' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return strconv.Quote(name)
+		}
+	}
+
+	if name == "" {
+		return `""`
+	}
+
+	return name
+}
+
+func typeScriptLiteral(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return strconv.Quote(fmt.Sprint(value))
+	}
+
+	return string(b)
+}
+
+func typeScriptUnion(values []any) string {
+	literals := make([]string, 0, len(values))
+	for _, value := range values {
+		literals = append(literals, typeScriptLiteral(value))
+	}
+
+	return strings.Join(literals, " | ")
+}
+
+func typeScriptCommentText(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// typeScriptFieldKey normalizes a property name, so that a Go field name matches the
+// property generated from it by any naming strategy.
+func typeScriptFieldKey(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+func optionalMark(required bool) string {
+	if required {
+		return ""
+	}
+
+	return "?"
+}
+
+// stringSlice converts an extension value holding a list of strings.
+func stringSlice(value any) []string {
+	switch values := value.(type) {
+	case []string:
+		return values
+	case []any:
+		result := make([]string, 0, len(values))
+		for _, v := range values {
+			result = append(result, fmt.Sprint(v))
+		}
+
+		return result
+	}
+
+	return nil
+}
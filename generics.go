@@ -60,6 +60,7 @@ func (pkgDefs *PackagesDefinitions) getTypeFromGenericParam(genericParam string,
 			ParentSpec: typeSpecDef.ParentSpec,
 			SchemaName: "array_" + typeSpecDef.SchemaName,
 			NotUnique:  false,
+			typeArgs:   []string{genericParam},
 		}
 	}
 
@@ -96,6 +97,7 @@ func (pkgDefs *PackagesDefinitions) getTypeFromGenericParam(genericParam string,
 			ParentSpec: typeSpecDef.ParentSpec,
 			SchemaName: "map_" + parts[0] + "_" + typeSpecDef.SchemaName,
 			NotUnique:  false,
+			typeArgs:   []string{genericParam},
 		}
 	}
 	if IsGolangPrimitiveType(genericParam) {
@@ -194,6 +196,8 @@ func (pkgDefs *PackagesDefinitions) parametrizeGenericType(file *ast.File, origi
 			Assign: original.TypeSpec.Assign,
 		},
 		SchemaName: schemaName,
+		origin:     original,
+		typeArgs:   genericParams,
 	}
 	pkgDefs.uniqueDefinitions[name] = parametrizedTypeSpec
 
@@ -5,6 +5,9 @@ go 1.24.0
 require (
 	github.com/KyleBanks/depth v1.2.1
 	github.com/go-openapi/spec v0.22.1
+	github.com/gofrs/uuid v4.4.0+incompatible
+	github.com/pmezard/go-difflib v1.0.0
+	github.com/shopspring/decimal v1.4.0
 	github.com/stretchr/testify v1.11.1
 	github.com/urfave/cli/v2 v2.3.0
 	golang.org/x/sync v0.12.0
@@ -25,7 +28,6 @@ require (
 	github.com/go-openapi/swag/stringutils v0.25.1 // indirect
 	github.com/go-openapi/swag/typeutils v0.25.1 // indirect
 	github.com/go-openapi/swag/yamlutils v0.25.1 // indirect
-	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/russross/blackfriday/v2 v2.0.1 // indirect
 	github.com/shurcooL/sanitized_anchor_name v1.0.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
@@ -0,0 +1,79 @@
+package swag
+
+import (
+	"go/ast"
+	"strings"
+	"unicode"
+)
+
+// goDocText returns the text of the comments which are not annotations: neither
+// attributes nor the lines of their blocks, the lines of templates or directives like
+// //swag:nolint. The comments are the ones of an operation, with their blocks joined.
+func goDocText(comments []*ast.Comment, inTemplate []bool) string {
+	var lines []string
+
+	for i, comment := range comments {
+		if inTemplate[i] || !strings.HasPrefix(comment.Text, "//") || !isBlockLine(comment.Text) {
+			continue
+		}
+
+		// the space of the "// text" lines, the code lines being indented with tabs
+		text := strings.TrimPrefix(comment.Text, "//")
+		lines = append(lines, strings.TrimPrefix(text, " "))
+	}
+
+	text := commentText(lines)
+
+	// the "ShowAccount godoc" line the examples of swag start their comments with
+	if first, rest, _ := strings.Cut(text, "\n"); strings.HasSuffix(first, " godoc") && len(strings.Fields(first)) == 2 {
+		text = strings.TrimLeft(rest, "\n")
+	}
+
+	return text
+}
+
+// cutFirstSentence returns the first sentence of a text, on a single line, and the text
+// following it. The first sentence ends at the first period followed by a space which
+// does not follow a single capital letter, like in "U.S. ", or at the end of the first
+// paragraph.
+func cutFirstSentence(text string) (string, string) {
+	paragraph, rest, _ := strings.Cut(text, "\n\n")
+
+	runes := []rune(paragraph)
+	for i, r := range runes {
+		if r != '.' || (i+1 < len(runes) && !unicode.IsSpace(runes[i+1])) {
+			continue
+		}
+
+		if i > 0 && unicode.IsUpper(runes[i-1]) && (i == 1 || !unicode.IsLetter(runes[i-2])) {
+			continue
+		}
+
+		remainder := strings.TrimSpace(string(runes[i+1:]))
+		if remainder != "" && rest != "" {
+			remainder += "\n\n"
+		}
+
+		return strings.Join(strings.Fields(string(runes[:i+1])), " "), remainder + strings.TrimSpace(rest)
+	}
+
+	return strings.Join(strings.Fields(paragraph), " "), strings.TrimSpace(rest)
+}
+
+// applyGoDoc sets the summary of the operation to the first sentence of the Go doc text
+// of its comments, and the description to the rest of it, when they are not set.
+func (operation *Operation) applyGoDoc(comments []*ast.Comment, inTemplate []bool) {
+	if operation.Summary != "" && operation.Description != "" {
+		return
+	}
+
+	summary, description := cutFirstSentence(goDocText(comments, inTemplate))
+
+	if operation.Summary == "" {
+		operation.Summary = summary
+	}
+
+	if operation.Description == "" {
+		operation.Description = description
+	}
+}
@@ -0,0 +1,123 @@
+package swag
+
+import (
+	"encoding/json"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoDoc(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/godoc"
+	p := New(SetGoDocDescriptions(true))
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
+
+func TestParseGoDoc_Disabled(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	err := p.ParseAPI("testdata/godoc", mainAPIFile, defaultParseDepth)
+	require.NoError(t, err)
+
+	operation := p.swagger.Paths.Paths["/users"].Get
+	require.NotNil(t, operation)
+	assert.Empty(t, operation.Summary)
+	assert.Empty(t, operation.Description)
+}
+
+func TestGoDocText(t *testing.T) {
+	t.Parallel()
+
+	var comments []*ast.Comment
+	for _, text := range []string{
+		"// ListUsers godoc",
+		"//",
+		"// ListUsers lists the users.",
+		"//",
+		"//	  indented",
+		"//swag:nolint",
+		"//	@Description |",
+		"//	  The block.",
+		"//	@Template paginated",
+		"//	@Param page query int false \"The page\"",
+		"//",
+		"//	@Router /users [get]",
+	} {
+		comments = append(comments, &ast.Comment{Text: text})
+	}
+
+	comments = joinCommentBlocks(comments)
+
+	lines := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		lines = append(lines, comment.Text)
+	}
+
+	assert.Equal(t, "ListUsers lists the users.\n\n\t  indented", goDocText(comments, templateBlockLines(lines)))
+}
+
+func TestCutFirstSentence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text        string
+		summary     string
+		description string
+	}{
+		{"", "", ""},
+		{"Lists the users", "Lists the users", ""},
+		{"Lists the users.", "Lists the users.", ""},
+		{"Lists the users. Sorted by name.", "Lists the users.", "Sorted by name."},
+		{"Lists the users\nof the API. Sorted\nby name.", "Lists the users of the API.", "Sorted\nby name."},
+		{"Lists the U.S. users. Sorted.", "Lists the U.S. users.", "Sorted."},
+		{"Lists the users of the API. Sorted.", "Lists the users of the API.", "Sorted."},
+		{"Uses v1.2 of the API.", "Uses v1.2 of the API.", ""},
+		{"Lists the users\n\nSorted by name.", "Lists the users", "Sorted by name."},
+		{"Lists the users. Sorted.\n\nPaginated.", "Lists the users.", "Sorted.\n\nPaginated."},
+	}
+
+	for _, test := range tests {
+		summary, description := cutFirstSentence(test.text)
+		assert.Equal(t, test.summary, summary, test.text)
+		assert.Equal(t, test.description, description, test.text)
+	}
+}
+
+func TestOperation_ApplyGoDoc(t *testing.T) {
+	t.Parallel()
+
+	comments := []*ast.Comment{
+		{Text: "// ListUsers lists the users. Sorted by name."},
+		{Text: "//	@Router /users [get]"},
+	}
+	inTemplate := []bool{false, false}
+
+	operation := NewOperation(nil)
+	operation.applyGoDoc(comments, inTemplate)
+	assert.Equal(t, "ListUsers lists the users.", operation.Summary)
+	assert.Equal(t, "Sorted by name.", operation.Description)
+
+	operation = NewOperation(nil)
+	operation.Summary = "List the users"
+	operation.applyGoDoc(comments, inTemplate)
+	assert.Equal(t, "List the users", operation.Summary)
+	assert.Equal(t, "Sorted by name.", operation.Description)
+
+	operation = NewOperation(nil)
+	operation.Description = "The users."
+	operation.applyGoDoc(comments, inTemplate)
+	assert.Equal(t, "ListUsers lists the users.", operation.Summary)
+	assert.Equal(t, "The users.", operation.Description)
+}
@@ -0,0 +1,134 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+)
+
+const basePathAttr = "@basepath"
+
+// packageDocFile is the name of the file whose package doc comment applies to the whole
+// package, the others applying to the operations of their own file.
+const packageDocFile = "doc.go"
+
+// routerGroup holds the annotations of a package doc comment, applied to the operations
+// declared in its scope like the route groups of gin, echo or chi.
+type routerGroup struct {
+	// basePath prefixes the paths of the operations
+	basePath string
+	lines    []string
+
+	// file is the ast.File the annotations are written in, where their types are resolved
+	file *ast.File
+}
+
+// collectRouterGroups registers the annotations of the package doc comment of the file,
+// for the package if the file is a doc.go and for the file otherwise. The doc comment of
+// the file holding the general API info is not a group.
+func (parser *Parser) collectRouterGroups(fileInfo *AstFileInfo) error {
+	if (fileInfo.ParseFlag&ParseOperations) == ParseNone || fileInfo.File.Doc == nil {
+		return nil
+	}
+
+	if path, _ := filepath.Abs(fileInfo.Path); path == parser.generalInfoPath {
+		return nil
+	}
+
+	group := &routerGroup{file: fileInfo.File}
+
+	for _, comment := range fileInfo.File.Doc.List {
+		line := strings.TrimSpace(strings.TrimLeft(comment.Text, "/"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+
+		fields := FieldsByAnySpace(line, 2)
+		if strings.ToLower(fields[0]) == basePathAttr {
+			if len(fields) != 2 {
+				return fmt.Errorf("%s needs a path in file %s", fields[0], fileInfo.Path)
+			}
+
+			group.basePath = fields[1]
+
+			continue
+		}
+
+		group.lines = append(group.lines, line)
+	}
+
+	if group.basePath == "" && len(group.lines) == 0 {
+		return nil
+	}
+
+	if filepath.Base(fileInfo.Path) == packageDocFile {
+		parser.packageGroups[fileInfo.PackagePath] = group
+	} else {
+		parser.fileGroups[fileInfo.File] = group
+	}
+
+	return nil
+}
+
+// applyRouterGroups completes the operation with the annotations of the groups of its
+// file and package, the ones of the operation and then of the file taking precedence.
+func (operation *Operation) applyRouterGroups(fileInfo *AstFileInfo) error {
+	for _, group := range []*routerGroup{
+		operation.parser.fileGroups[fileInfo.File],
+		operation.parser.packageGroups[fileInfo.PackagePath],
+	} {
+		if group == nil {
+			continue
+		}
+
+		err := operation.applyRouterGroup(group)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (operation *Operation) applyRouterGroup(group *routerGroup) error {
+	groupOperation, headers, err := operation.parseTemplateLines(group.lines, group.file)
+	if err != nil {
+		return err
+	}
+
+	nested, err := groupOperation.inheritTemplates(nil)
+	if err != nil {
+		return err
+	}
+
+	operation.inherit(&groupOperation.Operation)
+
+	for _, header := range append(headers, nested...) {
+		err = operation.inheritHeader(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range operation.RouterProperties {
+		operation.RouterProperties[i].Path = joinBasePath(group.basePath, operation.RouterProperties[i].Path)
+	}
+
+	return nil
+}
+
+// joinBasePath prefixes the path with the base path of a group, so that /admin and
+// /users/{id} give /admin/users/{id}.
+func joinBasePath(basePath, path string) string {
+	basePath = "/" + strings.Trim(basePath, "/")
+	if basePath == "/" {
+		return path
+	}
+
+	if path == "" || path == "/" {
+		return basePath
+	}
+
+	return basePath + "/" + strings.TrimPrefix(path, "/")
+}
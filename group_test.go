@@ -0,0 +1,67 @@
+package swag
+
+import (
+	"encoding/json"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRouterGroups(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/router_groups"
+	p := New()
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
+
+func TestParser_collectRouterGroups(t *testing.T) {
+	t.Parallel()
+
+	collect := func(p *Parser, path, src string) error {
+		f, err := goparser.ParseFile(token.NewFileSet(), path, src, goparser.ParseComments)
+		require.NoError(t, err)
+
+		return p.collectRouterGroups(&AstFileInfo{File: f, Path: path, PackagePath: "api", ParseFlag: ParseAll})
+	}
+
+	p := New()
+	require.NoError(t, collect(p, "api/doc.go", "// Package api.\n//\n// @BasePath /api/\n// @Tags api\npackage api\n"))
+	require.NoError(t, collect(p, "api/users.go", "// @BasePath users\npackage api\n"))
+	require.NoError(t, collect(p, "api/orders.go", "// Package api holds no annotation here.\npackage api\n"))
+
+	require.Contains(t, p.packageGroups, "api")
+	assert.Equal(t, "/api/", p.packageGroups["api"].basePath)
+	assert.Equal(t, []string{"@Tags api"}, p.packageGroups["api"].lines)
+	require.Len(t, p.fileGroups, 1)
+
+	operation := NewOperation(p)
+	require.NoError(t, operation.ParseComment("@Router /{id} [get]", nil))
+	for file := range p.fileGroups {
+		require.NoError(t, operation.applyRouterGroups(&AstFileInfo{File: file, PackagePath: "api"}))
+	}
+	assert.Equal(t, "/api/users/{id}", operation.RouterProperties[0].Path)
+	assert.Equal(t, []string{"api"}, operation.Tags)
+
+	assert.EqualError(t, collect(New(), "api/users.go", "// @BasePath\npackage api\n"), "@BasePath needs a path in file api/users.go")
+}
+
+func TestJoinBasePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/users", joinBasePath("", "/users"))
+	assert.Equal(t, "/users", joinBasePath("/", "/users"))
+	assert.Equal(t, "/admin/users", joinBasePath("/admin/", "/users"))
+	assert.Equal(t, "/admin/users", joinBasePath("admin", "users"))
+	assert.Equal(t, "/admin", joinBasePath("/admin", "/"))
+}
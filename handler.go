@@ -0,0 +1,326 @@
+package swag
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"sigs.k8s.io/yaml"
+)
+
+// HandlerConfig configures the handler serving the registered swagger documents.
+type HandlerConfig struct {
+	// Substitute replaces the host and schemes of the documents with the ones the request
+	// was made to.
+	Substitute bool
+	// TrustForwardedHeaders makes substitution prefer the X-Forwarded-Host and
+	// X-Forwarded-Proto headers set by proxies, and prefix the base path with
+	// X-Forwarded-Prefix.
+	TrustForwardedHeaders bool
+}
+
+// SubstituteRequestHost makes the handler serve the documents with the host and schemes
+// of the request, read from the X-Forwarded-* headers if trustForwardedHeaders is true.
+func SubstituteRequestHost(trustForwardedHeaders bool) func(*HandlerConfig) {
+	return func(config *HandlerConfig) {
+		config.Substitute = true
+		config.TrustForwardedHeaders = trustForwardedHeaders
+	}
+}
+
+// HandlerIndex is the body of the index served by the handler.
+type HandlerIndex struct {
+	Instances []HandlerIndexEntry `json:"instances"`
+}
+
+// HandlerIndexEntry links to the documents of a registered instance.
+type HandlerIndexEntry struct {
+	Name string `json:"name"`
+	JSON string `json:"json"`
+	YAML string `json:"yaml"`
+}
+
+type handler struct {
+	config HandlerConfig
+}
+
+// Handler returns a http.Handler serving every registered swagger instance. The handler
+// expects to be mounted at the root of its path, e.g. with http.StripPrefix:
+//
+//	/              lists the registered instances
+//	/{name}        the document, as JSON or YAML depending on the Accept header
+//	/{name}.json   the document as JSON
+//	/{name}.yaml   the document as YAML
+//
+// Responses carry an ETag, honor If-None-Match and are gzip encoded when accepted.
+func Handler(options ...func(*HandlerConfig)) http.Handler {
+	h := &handler{}
+
+	for _, option := range options {
+		option(&h.config)
+	}
+
+	return h
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	name := strings.Trim(r.URL.Path, "/")
+	if name == "" {
+		h.serveIndex(w, r)
+
+		return
+	}
+
+	format := ""
+
+	if GetSwagger(name) == nil {
+		extension := path.Ext(name)
+		switch extension {
+		case ".json":
+			format = "json"
+		case ".yaml", ".yml":
+			format = "yaml"
+		default:
+			http.NotFound(w, r)
+
+			return
+		}
+
+		name = strings.TrimSuffix(name, extension)
+	}
+
+	doc, err := ReadDoc(name)
+	if err != nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	if format == "" {
+		format = negotiateFormat(r.Header.Get("Accept"))
+		w.Header().Add("Vary", "Accept")
+	}
+
+	body := []byte(doc)
+
+	if h.config.Substitute {
+		if body, err = h.substitute(body, r); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Add("Vary", "Host")
+
+		if h.config.TrustForwardedHeaders {
+			w.Header().Add("Vary", "X-Forwarded-Host, X-Forwarded-Proto, X-Forwarded-Prefix")
+		}
+	}
+
+	contentType := "application/json; charset=utf-8"
+
+	if format == "yaml" {
+		if body, err = yaml.JSONToYAML(body); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		contentType = "application/yaml; charset=utf-8"
+	}
+
+	writeDocument(w, r, contentType, body)
+}
+
+func (h *handler) serveIndex(w http.ResponseWriter, r *http.Request) {
+	prefix := ""
+	if requestPath := mountedPath(r); !strings.HasSuffix(requestPath, "/") {
+		// relative links must resolve next to the index, not next to its parent
+		prefix = path.Base(requestPath) + "/"
+	}
+
+	index := HandlerIndex{Instances: []HandlerIndexEntry{}}
+
+	for _, name := range RegisteredNames() {
+		index.Instances = append(index.Instances, HandlerIndexEntry{
+			Name: name,
+			JSON: prefix + name + ".json",
+			YAML: prefix + name + ".yaml",
+		})
+	}
+
+	body, err := json.MarshalIndent(index, "", "    ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	writeDocument(w, r, "application/json; charset=utf-8", body)
+}
+
+// mountedPath returns the path the request was made to, which holds the path the handler
+// is mounted at, unlike its URL once stripped of it by http.StripPrefix.
+func mountedPath(r *http.Request) string {
+	if requestURL, err := url.ParseRequestURI(r.RequestURI); err == nil {
+		return requestURL.Path
+	}
+
+	return r.URL.Path
+}
+
+// substitute replaces the host, schemes and base path of doc with the ones of r.
+func (h *handler) substitute(doc []byte, r *http.Request) ([]byte, error) {
+	var swagger spec.Swagger
+	if err := json.Unmarshal(doc, &swagger); err != nil {
+		return nil, err
+	}
+
+	host, scheme, prefix := r.Host, "http", ""
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	if h.config.TrustForwardedHeaders {
+		if forwarded := firstHeaderValue(r, "X-Forwarded-Host"); forwarded != "" {
+			host = forwarded
+		}
+
+		if forwarded := firstHeaderValue(r, "X-Forwarded-Proto"); forwarded != "" {
+			scheme = forwarded
+		}
+
+		prefix = strings.TrimSuffix(firstHeaderValue(r, "X-Forwarded-Prefix"), "/")
+	}
+
+	swagger.Host = host
+	swagger.Schemes = []string{scheme}
+
+	if prefix != "" {
+		swagger.BasePath = prefix + "/" + strings.TrimPrefix(swagger.BasePath, "/")
+	}
+
+	return json.MarshalIndent(&swagger, "", "    ")
+}
+
+// writeDocument writes body with an ETag, answering If-None-Match with 304 Not Modified
+// and compressing body when the client accepts gzip.
+func writeDocument(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := w.Header()
+	header.Set("ETag", etag)
+	header.Add("Vary", "Accept-Encoding")
+
+	if matchETag(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+
+		return
+	}
+
+	header.Set("Content-Type", contentType)
+
+	if acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		var buffer bytes.Buffer
+
+		gz := gzip.NewWriter(&buffer)
+		_, _ = gz.Write(body)
+		_ = gz.Close()
+
+		body = buffer.Bytes()
+
+		header.Set("Content-Encoding", "gzip")
+	}
+
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+
+	if r.Method != http.MethodHead {
+		_, _ = w.Write(body)
+	}
+}
+
+func matchETag(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// negotiateFormat picks json or yaml from the media ranges of an Accept header,
+// preferring json unless yaml has a higher quality.
+func negotiateFormat(accept string) string {
+	jsonQuality, yamlQuality := -1.0, -1.0
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, quality := parseMediaRange(mediaRange)
+
+		switch {
+		case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+			jsonQuality = max(jsonQuality, quality)
+		case strings.HasSuffix(mediaType, "/yaml"), strings.HasSuffix(mediaType, "/x-yaml"), strings.HasSuffix(mediaType, "+yaml"):
+			yamlQuality = max(yamlQuality, quality)
+		}
+	}
+
+	if yamlQuality > 0 && yamlQuality > jsonQuality {
+		return "yaml"
+	}
+
+	return "json"
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, quality := parseMediaRange(coding)
+		if (name == "gzip" || name == "*") && quality > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseMediaRange splits an element of an Accept or Accept-Encoding header into its
+// lowercase value and quality.
+func parseMediaRange(mediaRange string) (string, float64) {
+	parts := strings.Split(mediaRange, ";")
+	quality := 1.0
+
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if strings.EqualFold(key, "q") {
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				quality = q
+			}
+		}
+	}
+
+	return strings.ToLower(strings.TrimSpace(parts[0])), quality
+}
+
+func firstHeaderValue(r *http.Request, name string) string {
+	value, _, _ := strings.Cut(r.Header.Get(name), ",")
+
+	return strings.TrimSpace(value)
+}
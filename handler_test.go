@@ -0,0 +1,181 @@
+package swag
+
+import (
+	"compress/gzip"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const handlerDoc = `{
+    "swagger": "2.0",
+    "info": {
+        "title": "Pets",
+        "version": "1.0"
+    },
+    "host": "localhost:8080",
+    "basePath": "/api/v1",
+    "paths": {}
+}`
+
+func serveHandler(handler http.Handler, r *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	setup()
+	Register(Name, &Spec{SwaggerTemplate: handlerDoc})
+	Register("admin", &Spec{SwaggerTemplate: handlerDoc})
+
+	handler := Handler()
+
+	t.Run("index", func(t *testing.T) {
+		w := serveHandler(handler, httptest.NewRequest(http.MethodGet, "/", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var index HandlerIndex
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &index))
+		assert.Equal(t, []HandlerIndexEntry{
+			{Name: "admin", JSON: "admin.json", YAML: "admin.yaml"},
+			{Name: "swagger", JSON: "swagger.json", YAML: "swagger.yaml"},
+		}, index.Instances)
+	})
+
+	t.Run("index without trailing slash", func(t *testing.T) {
+		for _, test := range []struct {
+			mount, target, prefix string
+		}{
+			{"/docs", "/docs", "docs/"},
+			{"/docs", "/docs/", ""},
+			{"/api/docs", "/api/docs?pretty", "docs/"},
+		} {
+			w := serveHandler(http.StripPrefix(test.mount, handler), httptest.NewRequest(http.MethodGet, test.target, nil))
+			require.Equal(t, http.StatusOK, w.Code, test.target)
+
+			var index HandlerIndex
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &index))
+			assert.Equal(t, test.prefix+"admin.json", index.Instances[0].JSON, test.target)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		for _, target := range []string{"/swagger", "/swagger.json", "/admin.json"} {
+			w := serveHandler(handler, httptest.NewRequest(http.MethodGet, target, nil))
+			require.Equal(t, http.StatusOK, w.Code, target)
+			assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, handlerDoc, w.Body.String())
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		expected := `basePath: /api/v1
+host: localhost:8080
+info:
+  title: Pets
+  version: "1.0"
+paths: {}
+swagger: "2.0"
+`
+
+		for _, r := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/swagger.yaml", nil),
+			httptest.NewRequest(http.MethodGet, "/swagger.yml", nil),
+			httptest.NewRequest(http.MethodGet, "/swagger", nil),
+		} {
+			r.Header.Set("Accept", "application/json;q=0.5, application/yaml")
+
+			w := serveHandler(handler, r)
+			require.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/yaml; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Equal(t, expected, w.Body.String())
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serveHandler(handler, httptest.NewRequest(http.MethodGet, "/missing.json", nil)).Code)
+		assert.Equal(t, http.StatusNotFound, serveHandler(handler, httptest.NewRequest(http.MethodGet, "/missing", nil)).Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		w := serveHandler(handler, httptest.NewRequest(http.MethodPost, "/swagger.json", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+	})
+
+	t.Run("etag", func(t *testing.T) {
+		w := serveHandler(handler, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+		etag := w.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+		r.Header.Set("If-None-Match", `"other", `+etag)
+
+		w = serveHandler(handler, r)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+		r.Header.Set("Accept-Encoding", "br, gzip")
+
+		w := serveHandler(handler, r)
+		require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+
+		b, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, handlerDoc, string(b))
+	})
+}
+
+func TestHandler_Substitute(t *testing.T) {
+	setup()
+	Register(Name, &Spec{SwaggerTemplate: handlerDoc})
+
+	read := func(t *testing.T, handler http.Handler, r *http.Request) map[string]any {
+		w := serveHandler(handler, r)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+
+		return doc
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "https://example.com/swagger.json", nil)
+	r.TLS = &tls.ConnectionState{}
+	r.Header.Set("X-Forwarded-Host", "api.example.com")
+	r.Header.Set("X-Forwarded-Proto", "http")
+	r.Header.Set("X-Forwarded-Prefix", "/pets/")
+
+	doc := read(t, Handler(SubstituteRequestHost(false)), r)
+	assert.Equal(t, "example.com", doc["host"])
+	assert.Equal(t, []any{"https"}, doc["schemes"])
+	assert.Equal(t, "/api/v1", doc["basePath"])
+
+	doc = read(t, Handler(SubstituteRequestHost(true)), r)
+	assert.Equal(t, "api.example.com", doc["host"])
+	assert.Equal(t, []any{"http"}, doc["schemes"])
+	assert.Equal(t, "/pets/api/v1", doc["basePath"])
+}
+
+func TestRegisteredNames(t *testing.T) {
+	setup()
+	assert.Empty(t, RegisteredNames())
+
+	Register("b", &s{})
+	Register("a", &s{})
+	assert.Equal(t, []string{"a", "b"}, RegisteredNames())
+}
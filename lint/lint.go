@@ -0,0 +1,523 @@
+// Package lint reports the swag annotations of an API which are missing, inconsistent or
+// ignored by the parser.
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+)
+
+// nolintDirective is the comment suppressing rules for an operation or a model, followed
+// by the names of the rules, comma separated, or by nothing to suppress every rule.
+const nolintDirective = "//swag:nolint"
+
+// Issue is a violation of a rule.
+type Issue struct {
+	Rule     string
+	Position token.Position
+	Message  string
+}
+
+// String returns the issue as file:line:column: message (rule).
+func (issue Issue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", issue.Position, issue.Message, issue.Rule)
+}
+
+// Diagnostic returns the issue as a warning diagnostic, coded with its rule.
+func (issue Issue) Diagnostic() swag.Diagnostic {
+	return swag.Diagnostic{
+		File:     issue.Position.Filename,
+		Line:     issue.Position.Line,
+		Column:   issue.Position.Column,
+		Severity: swag.SeverityWarning,
+		Code:     issue.Rule,
+		Message:  issue.Message,
+	}
+}
+
+// Rule is a check of the annotations.
+type Rule struct {
+	Name        string
+	Description string
+
+	operation func(l *linter, operation *operation)
+	model     func(l *linter, model *model)
+}
+
+// Rules are the rules of the linter.
+var Rules = []Rule{
+	{
+		Name:        "summary",
+		Description: "operations have a @Summary",
+		operation:   checkSummary,
+	},
+	{
+		Name:        "operation-id",
+		Description: "operations have an @ID",
+		operation:   checkOperationID,
+	},
+	{
+		Name:        "unknown-path-param",
+		Description: "the path parameters of operations appear in their @Router paths",
+		operation:   checkUnknownPathParams,
+	},
+	{
+		Name:        "missing-path-param",
+		Description: "the placeholders of @Router paths are declared with a @Param",
+		operation:   checkMissingPathParams,
+	},
+	{
+		Name:        "failure-code",
+		Description: "@Failure codes are not in the 2xx range",
+		operation:   checkFailureCodes,
+	},
+	{
+		Name:        "unknown-attribute",
+		Description: "the attributes of operations are understood by the parser",
+		operation:   checkUnknownAttributes,
+	},
+	{
+		Name:        "undeclared-tag",
+		Description: "the tags of operations are declared with @tag.name",
+		operation:   checkUndeclaredTags,
+	},
+	{
+		Name:        "model-description",
+		Description: "models have a description, taken from the doc comment of their type",
+		model:       checkModelDescription,
+	},
+}
+
+// Config specifies the API to lint and the rules to check.
+type Config struct {
+	// Project is the API to lint
+	gen.Project
+
+	// Rules are the names of the rules to check, all of them if empty
+	Rules []string
+
+	// DisabledRules are the names of the rules not to check
+	DisabledRules []string
+}
+
+// Linter checks the rules on the annotations of an API.
+type Linter struct{}
+
+// New creates a new Linter.
+func New() *Linter {
+	return &Linter{}
+}
+
+// Lint parses the API of config and returns the violations of its rules, sorted by
+// position.
+func (*Linter) Lint(config *Config) ([]Issue, error) {
+	rules, err := selectRules(config.Rules, config.DisabledRules)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, err := config.Parse(swag.SetDebugger(quietDebugger{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return lint(parser, rules), nil
+}
+
+func selectRules(names, disabled []string) ([]Rule, error) {
+	known := make(map[string]bool, len(Rules))
+	for _, rule := range Rules {
+		known[rule.Name] = true
+	}
+
+	enabled := make(map[string]bool, len(names))
+
+	for _, name := range append(append([]string{}, names...), disabled...) {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown lint rule %s", name)
+		}
+	}
+
+	for _, name := range names {
+		enabled[name] = true
+	}
+
+	var rules []Rule
+
+	for _, rule := range Rules {
+		if len(names) > 0 && !enabled[rule.Name] || contains(disabled, rule.Name) {
+			continue
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func lint(parser *swag.Parser, rules []Rule) []Issue {
+	l := &linter{swagger: parser.GetSwagger()}
+
+	for _, parsed := range parser.ParsedOperations() {
+		op := newOperation(parser, parsed)
+
+		for _, rule := range rules {
+			if rule.operation != nil && !op.suppressed(rule.Name) {
+				l.rule = rule.Name
+				rule.operation(l, op)
+			}
+		}
+	}
+
+	for name, typeSpec := range parser.GetDefinitionTypeSpecs() {
+		if typeSpec.TypeSpec == nil {
+			continue
+		}
+
+		m := &model{name: name, typeSpec: typeSpec, position: parser.Position(typeSpec.File, typeSpec.TypeSpec.Pos())}
+
+		for _, rule := range rules {
+			if rule.model != nil && !m.suppressed(rule.Name) {
+				l.rule = rule.Name
+				rule.model(l, m)
+			}
+		}
+	}
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		a, b := l.issues[i], l.issues[j]
+		if a.Position.Filename != b.Position.Filename {
+			return a.Position.Filename < b.Position.Filename
+		}
+
+		if a.Position.Line != b.Position.Line {
+			return a.Position.Line < b.Position.Line
+		}
+
+		return a.Rule < b.Rule
+	})
+
+	return l.issues
+}
+
+// linter collects the issues of the rules.
+type linter struct {
+	swagger *spec.Swagger
+	rule    string
+	issues  []Issue
+}
+
+func (l *linter) report(position token.Position, format string, args ...any) {
+	l.issues = append(l.issues, Issue{Rule: l.rule, Position: position, Message: fmt.Sprintf(format, args...)})
+}
+
+// operation is a parsed operation with the positions of its attributes.
+type operation struct {
+	swag.ParsedOperation
+
+	lines    []line
+	position token.Position
+	nolint   []string
+}
+
+// line is an attribute line of the comments of an operation.
+type line struct {
+	attribute string
+	value     string
+	position  token.Position
+}
+
+func newOperation(parser *swag.Parser, parsed swag.ParsedOperation) *operation {
+	op := &operation{ParsedOperation: parsed}
+
+	for i, comment := range parsed.Comments {
+		position := parser.Position(parsed.File.File, comment.Pos())
+		if i == 0 {
+			op.position = position
+		}
+
+		if rules, ok := nolintRules(comment.Text); ok {
+			op.nolint = append(op.nolint, rules...)
+
+			continue
+		}
+
+		text := strings.TrimSpace(strings.TrimLeft(comment.Text, "/"))
+		if !strings.HasPrefix(text, "@") {
+			continue
+		}
+
+		fields := swag.FieldsByAnySpace(text, 2)
+		attributeLine := line{attribute: fields[0], position: position}
+		if len(fields) > 1 {
+			attributeLine.value = fields[1]
+		}
+
+		op.lines = append(op.lines, attributeLine)
+	}
+
+	if router := op.find("@router", ""); router != nil {
+		op.position = router.position
+	}
+
+	return op
+}
+
+// find returns the first line of the attribute whose value starts with the given prefix
+// field, nil if there is none.
+func (op *operation) find(attribute, field string) *line {
+	for i, attributeLine := range op.lines {
+		if strings.ToLower(attributeLine.attribute) != attribute {
+			continue
+		}
+
+		if field == "" || strings.HasPrefix(attributeLine.value+" ", field+" ") {
+			return &op.lines[i]
+		}
+	}
+
+	return nil
+}
+
+// positionOf returns the position of the first line of the attribute, or the one of the
+// operation if it has none, like for attributes inherited from templates.
+func (op *operation) positionOf(attribute, field string) token.Position {
+	if attributeLine := op.find(attribute, field); attributeLine != nil {
+		return attributeLine.position
+	}
+
+	return op.position
+}
+
+func (op *operation) suppressed(rule string) bool {
+	return suppressed(op.nolint, rule)
+}
+
+// model is a type definition written to the definitions of the document.
+type model struct {
+	name     string
+	typeSpec *swag.TypeSpecDef
+	position token.Position
+}
+
+func (m *model) suppressed(rule string) bool {
+	commentGroups := []*ast.CommentGroup{m.typeSpec.TypeSpec.Doc, m.typeSpec.TypeSpec.Comment}
+	if genDecl := m.genDecl(); genDecl != nil {
+		commentGroups = append(commentGroups, genDecl.Doc)
+	}
+
+	for _, commentGroup := range commentGroups {
+		if commentGroup == nil {
+			continue
+		}
+
+		for _, comment := range commentGroup.List {
+			if rules, ok := nolintRules(comment.Text); ok && suppressed(rules, rule) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// genDecl returns the declaration of the type, holding its doc comment when the type is
+// declared without parentheses.
+func (m *model) genDecl() *ast.GenDecl {
+	if genDecl, ok := m.typeSpec.ParentSpec.(*ast.GenDecl); ok {
+		return genDecl
+	}
+
+	if m.typeSpec.File == nil {
+		return nil
+	}
+
+	for _, decl := range m.typeSpec.File.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			if spec == m.typeSpec.TypeSpec {
+				return genDecl
+			}
+		}
+	}
+
+	return nil
+}
+
+// nolintRules returns the rules suppressed by a //swag:nolint comment, none meaning all.
+func nolintRules(comment string) ([]string, bool) {
+	if !strings.HasPrefix(comment, nolintDirective) {
+		return nil, false
+	}
+
+	rest := strings.TrimPrefix(comment, nolintDirective)
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false
+	}
+
+	rules := strings.FieldsFunc(rest, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	if len(rules) == 0 {
+		rules = []string{""}
+	}
+
+	return rules, true
+}
+
+func suppressed(rules []string, rule string) bool {
+	for _, name := range rules {
+		if name == "" || name == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+func checkSummary(l *linter, op *operation) {
+	if op.Summary == "" {
+		l.report(op.position, "operation has no @Summary")
+	}
+}
+
+func checkOperationID(l *linter, op *operation) {
+	if op.ID == "" {
+		l.report(op.position, "operation has no @ID")
+	}
+}
+
+var pathPlaceholderPattern = regexp.MustCompile(`{([^}]+)}`)
+
+// pathParams returns the names of the path parameters of the operation.
+func (l *linter) pathParams(op *operation) []string {
+	var names []string
+
+	for _, param := range op.Parameters {
+		if ref := param.Ref.String(); ref != "" {
+			param = l.swagger.Parameters[strings.TrimPrefix(ref, "#/parameters/")]
+		}
+
+		if param.In == "path" {
+			names = append(names, param.Name)
+		}
+	}
+
+	return names
+}
+
+func placeholders(path string) []string {
+	var names []string
+
+	for _, matches := range pathPlaceholderPattern.FindAllStringSubmatch(path, -1) {
+		// a placeholder may carry a pattern, like {id:[0-9]+}
+		name, _, _ := strings.Cut(matches[1], ":")
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func checkUnknownPathParams(l *linter, op *operation) {
+	var names []string
+	for _, router := range op.RouterProperties {
+		names = append(names, placeholders(router.Path)...)
+	}
+
+	for _, param := range l.pathParams(op) {
+		if !contains(names, param) {
+			l.report(op.positionOf("@param", param), "path parameter %s does not appear in the @Router path", param)
+		}
+	}
+}
+
+func checkMissingPathParams(l *linter, op *operation) {
+	params := l.pathParams(op)
+
+	for _, router := range op.RouterProperties {
+		for _, name := range placeholders(router.Path) {
+			if !contains(params, name) {
+				l.report(op.positionOf("@router", ""), "placeholder {%s} of path %s has no @Param", name, router.Path)
+			}
+		}
+	}
+}
+
+func checkFailureCodes(l *linter, op *operation) {
+	for _, attributeLine := range op.lines {
+		if strings.ToLower(attributeLine.attribute) != "@failure" {
+			continue
+		}
+
+		codes := swag.FieldsByAnySpace(attributeLine.value, 2)
+		if len(codes) == 0 {
+			continue
+		}
+
+		for _, code := range strings.Split(codes[0], ",") {
+			if statusCode, err := strconv.Atoi(code); err == nil && statusCode >= 200 && statusCode < 300 {
+				l.report(attributeLine.position, "@Failure code %d is a success code, use @Success", statusCode)
+			}
+		}
+	}
+}
+
+func checkUnknownAttributes(l *linter, op *operation) {
+	for _, attributeLine := range op.lines {
+		if !swag.IsOperationAttribute(attributeLine.attribute) {
+			l.report(attributeLine.position, "unknown attribute %s is ignored", attributeLine.attribute)
+		}
+	}
+}
+
+func checkUndeclaredTags(l *linter, op *operation) {
+	for _, tag := range op.Tags {
+		declared := false
+
+		for _, declaredTag := range l.swagger.Tags {
+			if declaredTag.Name == tag {
+				declared = true
+
+				break
+			}
+		}
+
+		if !declared {
+			l.report(op.positionOf("@tags", ""), "tag %s is not declared with @tag.name", tag)
+		}
+	}
+}
+
+func checkModelDescription(l *linter, m *model) {
+	if l.swagger.Definitions[m.name].Description == "" {
+		l.report(m.position, "model %s has no description", m.name)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// quietDebugger discards the output of the parser.
+type quietDebugger struct{}
+
+func (quietDebugger) Printf(string, ...any) {}
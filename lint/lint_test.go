@@ -0,0 +1,116 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+)
+
+var project = gen.Project{SearchDir: "../testdata/lint", MainAPIFile: "main.go", ParseDepth: 100}
+
+// summary returns the issues as file:line rule.
+func summary(issues []Issue) []string {
+	var lines []string
+	for _, issue := range issues {
+		lines = append(lines, fmt.Sprintf("%s:%d %s", filepath.Base(issue.Position.Filename), issue.Position.Line, issue.Rule))
+	}
+
+	return lines
+}
+
+func TestLinter_Lint(t *testing.T) {
+	issues, err := New().Lint(&Config{Project: project})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"api.go:23 undeclared-tag",
+		"api.go:24 unknown-path-param",
+		"api.go:25 unknown-attribute",
+		"api.go:26 failure-code",
+		"api.go:27 missing-path-param",
+		"api.go:27 operation-id",
+		"api.go:27 summary",
+		"model.go:12 model-description",
+	}, summary(issues))
+
+	assert.Equal(t, "../testdata/lint/api/api.go:25:1: unknown attribute @Sucess is ignored (unknown-attribute)", issues[2].String())
+	assert.Equal(t, swag.Diagnostic{
+		File:     "../testdata/lint/api/api.go",
+		Line:     25,
+		Column:   1,
+		Severity: swag.SeverityWarning,
+		Code:     "unknown-attribute",
+		Message:  "unknown attribute @Sucess is ignored",
+	}, issues[2].Diagnostic())
+}
+
+func TestLinter_LintNolint(t *testing.T) {
+	issues, err := New().Lint(&Config{Project: project})
+	require.NoError(t, err)
+
+	for _, issue := range issues {
+		// DeleteUser disables operation-id and undeclared-tag, and Group every rule.
+		assert.NotEqual(t, 37, issue.Position.Line, issue.String())
+		assert.NotContains(t, issue.Message, "model.Group", issue.String())
+	}
+}
+
+func TestLinter_LintRules(t *testing.T) {
+	issues, err := New().Lint(&Config{
+		Project: project,
+		Rules:   []string{"summary", "operation-id"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api.go:27 operation-id", "api.go:27 summary"}, summary(issues))
+
+	issues, err = New().Lint(&Config{
+		Project:       project,
+		DisabledRules: []string{"model-description", "undeclared-tag", "unknown-attribute"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"api.go:24 unknown-path-param",
+		"api.go:26 failure-code",
+		"api.go:27 missing-path-param",
+		"api.go:27 operation-id",
+		"api.go:27 summary",
+	}, summary(issues))
+}
+
+func TestLinter_LintUnknownRule(t *testing.T) {
+	_, err := New().Lint(&Config{Project: project, Rules: []string{"summary", "nothing"}})
+	assert.EqualError(t, err, "unknown lint rule nothing")
+
+	_, err = New().Lint(&Config{Project: project, DisabledRules: []string{"nothing"}})
+	assert.EqualError(t, err, "unknown lint rule nothing")
+}
+
+func TestLinter_LintMarkdownFiles(t *testing.T) {
+	_, err := New().Lint(&Config{Project: gen.Project{
+		SearchDir:        "../testdata/tags",
+		MainAPIFile:      "main.go",
+		MarkdownFilesDir: "../testdata/tags",
+		ParseDepth:       100,
+	}})
+	assert.NoError(t, err)
+}
+
+func TestNolintRules(t *testing.T) {
+	rules, ok := nolintRules("//swag:nolint")
+	assert.True(t, ok)
+	assert.True(t, suppressed(rules, "summary"))
+
+	rules, ok = nolintRules("//swag:nolint summary,operation-id")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"summary", "operation-id"}, rules)
+	assert.False(t, suppressed(rules, "unknown-attribute"))
+
+	_, ok = nolintRules("// swag is a tool")
+	assert.False(t, ok)
+}
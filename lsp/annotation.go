@@ -0,0 +1,317 @@
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/swaggo/swag"
+)
+
+// annotation is a swag annotation of a comment line, split in fields.
+type annotation struct {
+	fields []field
+}
+
+// field is a field of an annotation, between the start and end byte offsets of its line.
+type field struct {
+	text       string
+	start, end int
+}
+
+// parseAnnotation returns the annotation of a comment line, false when the line is not
+// one, like // @Success 200 {object} model.User.
+func parseAnnotation(line string) (*annotation, bool) {
+	offset := strings.Index(line, "//")
+	if offset < 0 || strings.TrimSpace(line[:offset]) != "" {
+		return nil, false
+	}
+
+	var fields []field
+
+	start := -1
+
+	for i, r := range line[offset+2:] + " " {
+		i += offset + 2
+
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, field{text: line[start:i], start: start, end: i})
+				start = -1
+			}
+
+			continue
+		}
+
+		if start < 0 {
+			start = i
+		}
+	}
+
+	if len(fields) == 0 || !strings.HasPrefix(fields[0].text, "@") {
+		return nil, false
+	}
+
+	return &annotation{fields: fields}, true
+}
+
+// attribute returns the lower case attribute of the annotation, like @success.
+func (a *annotation) attribute() string {
+	return strings.ToLower(a.fields[0].text)
+}
+
+// fieldAt returns the index of the field at the byte offset, and the field, empty when
+// the offset is between fields.
+func (a *annotation) fieldAt(offset int) (int, field) {
+	for i, f := range a.fields {
+		if offset < f.start {
+			return i, field{start: offset, end: offset}
+		}
+
+		if offset <= f.end {
+			return i, f
+		}
+	}
+
+	return len(a.fields), field{start: offset, end: offset}
+}
+
+// paramLocations are the locations of the parameters of @Param.
+var paramLocations = []string{"path", "query", "header", "body", "formData"}
+
+// paramTypes are the primitive types of the parameters of @Param.
+var paramTypes = []string{"string", "integer", "number", "boolean", "int", "bool", "file"}
+
+// responseDataTypes are the data types of the responses of @Success, @Failure and
+// @Response.
+var responseDataTypes = []string{"{object}", "{array}", "{string}", "{integer}", "{number}", "{boolean}"}
+
+// complete returns the completion items at the position of a document.
+func (s *Server) complete(doc *document, pos position) []completionItem {
+	lines := doc.lines()
+	if pos.Line >= len(lines) {
+		return nil
+	}
+
+	line := lines[pos.Line]
+	offset := byteOffset(line, pos.Character)
+
+	a, ok := parseAnnotation(line)
+	if !ok {
+		return nil
+	}
+
+	index, f := a.fieldAt(offset)
+
+	// the edited part of the field, up to the cursor
+	f.end = offset
+
+	var (
+		labels []string
+		kind   int
+		detail map[string]string
+	)
+
+	switch attribute := a.attribute(); {
+	case index == 0:
+		labels, kind = s.attributeNames(doc), keywordCompletion
+	case (attribute == "@accept" || attribute == "@produce") && index == 1:
+		// the media types are comma separated
+		f.start += strings.LastIndex(line[f.start:offset], ",") + 1
+		labels, kind, detail = mimeTypeAliases()
+	case attribute == "@param" && index == 2:
+		labels, kind = paramLocations, valueCompletion
+	case attribute == "@param" && index == 3:
+		labels, detail = s.typeNames(doc)
+		labels, kind = append(append([]string{}, paramTypes...), labels...), classCompletion
+	case (attribute == "@success" || attribute == "@failure" || attribute == "@response") && index == 2:
+		labels, kind = responseDataTypes, valueCompletion
+	case (attribute == "@success" || attribute == "@failure" || attribute == "@response") && index == 3:
+		labels, detail = s.typeNames(doc)
+		kind = classCompletion
+	default:
+		return nil
+	}
+
+	editRange := textRange{
+		Start: position{Line: pos.Line, Character: utf16Len(line[:f.start])},
+		End:   pos,
+	}
+
+	items := make([]completionItem, 0, len(labels))
+
+	for _, label := range labels {
+		items = append(items, completionItem{
+			Label:    label,
+			Kind:     kind,
+			Detail:   detail[label],
+			TextEdit: &textEdit{Range: editRange, NewText: label},
+		})
+	}
+
+	return items
+}
+
+// attributeNames returns the attributes of operations, and of the general API info in its
+// file.
+func (s *Server) attributeNames(doc *document) []string {
+	names := swag.OperationAttributeNames()
+	if doc.path == absPath(filepath.Join(strings.Split(s.config.SearchDir, ",")[0], s.config.MainAPIFile)) {
+		names = append(names, swag.GeneralAttributeNames()...)
+	}
+
+	return names
+}
+
+// mimeTypeAliases returns the aliases of media types, detailed with their media type.
+func mimeTypeAliases() ([]string, int, map[string]string) {
+	aliases := swag.MimeTypeAliases()
+
+	labels := make([]string, 0, len(aliases))
+	for alias := range aliases {
+		labels = append(labels, alias)
+	}
+
+	sort.Strings(labels)
+
+	return labels, valueCompletion, aliases
+}
+
+// typeNames returns the names of the types of the workspace as written in the annotations
+// of the document, detailed with their package path.
+func (s *Server) typeNames(doc *document) ([]string, map[string]string) {
+	var (
+		labels []string
+		detail = make(map[string]string)
+	)
+
+	for _, typeSpec := range s.parser.Packages().TypeSpecs() {
+		if typeSpec.ParentSpec != nil || typeSpec.File == nil {
+			continue
+		}
+
+		label := typeSpec.File.Name.Name + "." + typeSpec.Name()
+
+		file := s.parser.Position(typeSpec.File, typeSpec.TypeSpec.Pos()).Filename
+		if file != "" && filepath.Dir(absPath(file)) == filepath.Dir(doc.path) {
+			label = typeSpec.Name()
+		}
+
+		if _, ok := detail[label]; ok {
+			continue
+		}
+
+		labels = append(labels, label)
+		detail[label] = typeSpec.PkgPath
+	}
+
+	return labels, detail
+}
+
+// typeAt returns the type definition named at the position of an annotation of the
+// document, and the range of its name.
+func (s *Server) typeAt(doc *document, pos position) (*swag.TypeSpecDef, textRange, bool) {
+	lines := doc.lines()
+	if pos.Line >= len(lines) {
+		return nil, textRange{}, false
+	}
+
+	line := lines[pos.Line]
+	offset := byteOffset(line, pos.Character)
+
+	a, ok := parseAnnotation(line)
+	if !ok {
+		return nil, textRange{}, false
+	}
+
+	if index, _ := a.fieldAt(offset); index == 0 {
+		return nil, textRange{}, false
+	}
+
+	isNamePart := func(r byte) bool {
+		return r == '_' || r == '.' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+	}
+
+	start, end := offset, offset
+	for start > 0 && isNamePart(line[start-1]) {
+		start--
+	}
+
+	for end < len(line) && isNamePart(line[end]) {
+		end++
+	}
+
+	name := strings.Trim(line[start:end], ".")
+	if name == "" || swag.IsGolangPrimitiveType(name) {
+		return nil, textRange{}, false
+	}
+
+	// the names are resolved from the imports of the saved file, known to the packages
+	astFile := s.workspaceFile(doc.path)
+	if astFile == nil {
+		return nil, textRange{}, false
+	}
+
+	typeSpec := s.parser.Packages().FindTypeSpec(name, astFile)
+	if typeSpec == nil || typeSpec.TypeSpec == nil {
+		return nil, textRange{}, false
+	}
+
+	return typeSpec, textRange{
+		Start: position{Line: pos.Line, Character: utf16Len(line[:start])},
+		End:   position{Line: pos.Line, Character: utf16Len(line[:end])},
+	}, true
+}
+
+// hover returns the schema of the type named at the position of a document.
+func (s *Server) hover(doc *document, pos position) *hover {
+	typeSpec, nameRange, ok := s.typeAt(doc, pos)
+	if !ok {
+		return nil
+	}
+
+	schema, err := s.parser.ParseDefinition(typeSpec)
+	if err != nil {
+		return &hover{
+			Contents: markupContent{Kind: "markdown", Value: fmt.Sprintf("**%s**\n\n%s", typeSpec.TypeName(), err)},
+			Range:    &nameRange,
+		}
+	}
+
+	b, err := json.MarshalIndent(schema.Schema, "", "  ")
+	if err != nil {
+		return nil
+	}
+
+	return &hover{
+		Contents: markupContent{
+			Kind:  "markdown",
+			Value: fmt.Sprintf("**%s** `%s`\n\n```json\n%s\n```", schema.Name, typeSpec.PkgPath, b),
+		},
+		Range: &nameRange,
+	}
+}
+
+// definition returns the location of the declaration of the type named at the position
+// of a document.
+func (s *Server) definition(doc *document, pos position) *location {
+	typeSpec, _, ok := s.typeAt(doc, pos)
+	if !ok {
+		return nil
+	}
+
+	start := s.parser.Position(typeSpec.File, typeSpec.TypeSpec.Name.Pos())
+	if start.Filename == "" {
+		return nil
+	}
+
+	path := absPath(start.Filename)
+	nameRange := lineRange(s.fileLines(path), start.Line, start.Column)
+	nameRange.End.Character = nameRange.Start.Character + utf16Len(typeSpec.TypeSpec.Name.Name)
+	nameRange.End.Line = nameRange.Start.Line
+
+	return &location{URI: pathToURI(path), Range: nameRange}
+}
@@ -0,0 +1,207 @@
+// Package lsp implements a Language Server Protocol server for the swag annotations of
+// Go files, offering completion, hover, go-to-definition and diagnostics.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+)
+
+// Config is the configuration of the parsing of the workspace.
+type Config struct {
+	// Project is the workspace, its SearchDir being the root of the workspace by default
+	gen.Project
+}
+
+// Server is a language server for the swag annotations of a workspace. The workspace is
+// parsed when the server is initialized and when a file is saved, and the operations of
+// a file are checked while it is edited.
+type Server struct {
+	config    Config
+	parser    *swag.Parser
+	documents map[string]*document
+	published map[string]bool
+	out       io.Writer
+}
+
+// New creates a new Server.
+func New() *Server {
+	return &Server{
+		documents: make(map[string]*document),
+		published: make(map[string]bool),
+	}
+}
+
+// Run serves the requests read from in, writing the responses to out, until the client
+// exits or in is closed.
+func (s *Server) Run(config *Config, in io.Reader, out io.Writer) error {
+	s.config = *config
+	s.out = out
+
+	if s.config.MainAPIFile == "" {
+		s.config.MainAPIFile = "main.go"
+	}
+
+	reader := bufio.NewReader(in)
+
+	for {
+		body, err := readMessage(reader)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		var req request
+
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			err = s.replyError(nil, parseErrorCode, err.Error())
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		err = s.handle(&req)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a request or a notification, returning the errors writing to the client.
+func (s *Server) handle(req *request) error {
+	switch req.Method {
+	case "initialize":
+		var params initializeParams
+		if !s.decode(req, &params) {
+			return s.replyError(req.ID, invalidParamsCode, "invalid initialize params")
+		}
+
+		if s.config.SearchDir == "" {
+			s.config.SearchDir = rootPath(&params)
+		}
+
+		return s.reply(req.ID, map[string]any{
+			"capabilities": map[string]any{
+				"textDocumentSync": map[string]any{
+					"openClose": true,
+					"change":    1, // the whole text of the document
+					"save":      true,
+				},
+				"completionProvider": map[string]any{
+					"triggerCharacters": []string{"@", " ", ","},
+				},
+				"hoverProvider":      true,
+				"definitionProvider": true,
+			},
+			"serverInfo": map[string]any{
+				"name":    "swag",
+				"version": swag.Version,
+			},
+		})
+	case "initialized", "textDocument/didSave":
+		err := s.load()
+		if err != nil {
+			return s.notify("window/logMessage", logMessageParams{Type: errorMessage, Message: err.Error()})
+		}
+
+		return s.publishWorkspaceDiagnostics()
+	case "shutdown":
+		return s.reply(req.ID, nil)
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if s.decode(req, &params) {
+			path := uriToPath(params.TextDocument.URI)
+			s.documents[path] = &document{path: path, text: params.TextDocument.Text}
+		}
+
+		return nil
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if !s.decode(req, &params) || len(params.ContentChanges) == 0 {
+			return nil
+		}
+
+		path := uriToPath(params.TextDocument.URI)
+		doc := &document{path: path, text: params.ContentChanges[len(params.ContentChanges)-1].Text}
+		s.documents[path] = doc
+
+		return s.publishDocumentDiagnostics(doc)
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if s.decode(req, &params) {
+			delete(s.documents, uriToPath(params.TextDocument.URI))
+		}
+
+		return nil
+	case "textDocument/completion", "textDocument/hover", "textDocument/definition":
+		var params textDocumentPositionParams
+		if !s.decode(req, &params) {
+			return s.replyError(req.ID, invalidParamsCode, "invalid "+req.Method+" params")
+		}
+
+		doc, ok := s.documents[uriToPath(params.TextDocument.URI)]
+		if !ok || s.parser == nil {
+			return s.reply(req.ID, nil)
+		}
+
+		switch req.Method {
+		case "textDocument/completion":
+			return s.reply(req.ID, s.complete(doc, params.Position))
+		case "textDocument/hover":
+			return s.reply(req.ID, s.hover(doc, params.Position))
+		default:
+			return s.reply(req.ID, s.definition(doc, params.Position))
+		}
+	default:
+		if req.ID != nil {
+			return s.replyError(req.ID, methodNotFoundCode, "method not found: "+req.Method)
+		}
+
+		return nil
+	}
+}
+
+func (s *Server) decode(req *request, params any) bool {
+	return len(req.Params) > 0 && json.Unmarshal(req.Params, params) == nil
+}
+
+func (s *Server) reply(id *json.RawMessage, result any) error {
+	return writeMessage(s.out, response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, message string) error {
+	return writeMessage(s.out, errorResponse{JSONRPC: "2.0", ID: id, Error: responseError{Code: code, Message: message}})
+}
+
+func (s *Server) notify(method string, params any) error {
+	return writeMessage(s.out, notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+// rootPath returns the root directory of the workspace of the client.
+func rootPath(params *initializeParams) string {
+	switch {
+	case params.RootURI != "":
+		return uriToPath(params.RootURI)
+	case len(params.WorkspaceFolders) > 0:
+		return uriToPath(params.WorkspaceFolders[0].URI)
+	case params.RootPath != "":
+		return absPath(params.RootPath)
+	default:
+		return absPath(".")
+	}
+}
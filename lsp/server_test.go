@@ -0,0 +1,273 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const searchDir = "../testdata/lsp"
+
+// message is a message sent by the server.
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// session runs a server on the messages, returning the messages it sent.
+func session(t *testing.T, messages ...map[string]any) []message {
+	t.Helper()
+
+	var in, out bytes.Buffer
+	for _, m := range messages {
+		m["jsonrpc"] = "2.0"
+		require.NoError(t, writeMessage(&in, m))
+	}
+
+	require.NoError(t, New().Run(&Config{}, &in, &out))
+
+	var sent []message
+
+	reader := bufio.NewReader(&out)
+	for reader.Buffered() > 0 || out.Len() > 0 {
+		body, err := readMessage(reader)
+		require.NoError(t, err)
+
+		var m message
+		require.NoError(t, json.Unmarshal(body, &m))
+		sent = append(sent, m)
+	}
+
+	return sent
+}
+
+// initialize returns the messages initializing a server on the testdata.
+func initialize(t *testing.T) []map[string]any {
+	t.Helper()
+
+	root, err := filepath.Abs(searchDir)
+	require.NoError(t, err)
+
+	return []map[string]any{
+		{"id": 1, "method": "initialize", "params": map[string]any{"rootUri": pathToURI(root)}},
+		{"method": "initialized", "params": map[string]any{}},
+	}
+}
+
+// open returns the message opening a file of the testdata.
+func open(t *testing.T, name string) map[string]any {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join(searchDir, name))
+	require.NoError(t, err)
+
+	return map[string]any{"method": "textDocument/didOpen", "params": map[string]any{
+		"textDocument": map[string]any{"uri": uri(name), "languageId": "go", "version": 1, "text": string(b)},
+	}}
+}
+
+// at returns the request of the method at a position of a file of the testdata.
+func at(id int, method, name string, line, character int) map[string]any {
+	return map[string]any{"id": id, "method": method, "params": map[string]any{
+		"textDocument": map[string]any{"uri": uri(name)},
+		"position":     map[string]any{"line": line, "character": character},
+	}}
+}
+
+func uri(name string) string {
+	return pathToURI(filepath.Join(searchDir, name))
+}
+
+// result returns the result of the response to the request id.
+func result(t *testing.T, messages []message, id int, v any) {
+	t.Helper()
+
+	for _, m := range messages {
+		if m.ID != nil && *m.ID == id && m.Method == "" {
+			require.Nil(t, m.Error)
+			require.NoError(t, json.Unmarshal(m.Result, v))
+
+			return
+		}
+	}
+
+	t.Fatalf("no response to request %d", id)
+}
+
+// published returns the last diagnostics published for a file of the testdata.
+func published(t *testing.T, messages []message, name string) []diagnostic {
+	t.Helper()
+
+	var params *publishDiagnosticsParams
+
+	for _, m := range messages {
+		if m.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+
+		var p publishDiagnosticsParams
+		require.NoError(t, json.Unmarshal(m.Params, &p))
+
+		if p.URI == uri(name) {
+			params = &p
+		}
+	}
+
+	require.NotNil(t, params, "no diagnostics published for %s", name)
+
+	return params.Diagnostics
+}
+
+func labels(items []completionItem) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Label)
+	}
+
+	return names
+}
+
+func TestServer_Initialize(t *testing.T) {
+	messages := session(t,
+		map[string]any{"id": 1, "method": "initialize", "params": map[string]any{"rootUri": uri("")}},
+		map[string]any{"id": 2, "method": "textDocument/rename", "params": map[string]any{}},
+		map[string]any{"id": 3, "method": "shutdown"},
+		map[string]any{"method": "exit"},
+		map[string]any{"id": 4, "method": "shutdown"},
+	)
+	require.Len(t, messages, 3)
+
+	var initialized struct {
+		Capabilities map[string]any `json:"capabilities"`
+		ServerInfo   struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	result(t, messages, 1, &initialized)
+	assert.Equal(t, "swag", initialized.ServerInfo.Name)
+	assert.Equal(t, true, initialized.Capabilities["hoverProvider"])
+	assert.Equal(t, true, initialized.Capabilities["definitionProvider"])
+	assert.Contains(t, initialized.Capabilities, "completionProvider")
+
+	require.NotNil(t, messages[1].Error)
+	assert.Equal(t, methodNotFoundCode, messages[1].Error.Code)
+
+	assert.Equal(t, "null", string(messages[2].Result))
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join(searchDir, "api/api.go"))
+	require.NoError(t, err)
+
+	fixed := strings.Replace(string(b), "model.Unknown", "model.User", 1)
+	broken := strings.Replace(string(b), "@Success 200", "@Success 2OO", 1)
+
+	change := func(text string) map[string]any {
+		return map[string]any{"method": "textDocument/didChange", "params": map[string]any{
+			"textDocument":   map[string]any{"uri": uri("api/api.go"), "version": 2},
+			"contentChanges": []map[string]any{{"text": text}},
+		}}
+	}
+
+	messages := session(t, append(initialize(t), open(t, "api/api.go"))...)
+
+	diagnostics := published(t, messages, "api/api.go")
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, 23, diagnostics[0].Range.Start.Line)
+	assert.Equal(t, errorSeverity, diagnostics[0].Severity)
+	assert.Equal(t, "swag", diagnostics[0].Source)
+	assert.Contains(t, diagnostics[0].Message, "model.Unknown")
+
+	messages = session(t, append(initialize(t), open(t, "api/api.go"), change(fixed))...)
+	assert.Empty(t, published(t, messages, "api/api.go"))
+
+	messages = session(t, append(initialize(t), open(t, "api/api.go"), change(broken))...)
+	diagnostics = published(t, messages, "api/api.go")
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, 15, diagnostics[0].Range.Start.Line)
+	assert.Equal(t, 23, diagnostics[1].Range.Start.Line)
+}
+
+func TestServer_Completion(t *testing.T) {
+	messages := session(t, append(initialize(t),
+		open(t, "api/api.go"),
+		open(t, "main.go"),
+		at(2, "textDocument/completion", "api/api.go", 12, 6),
+		at(3, "textDocument/completion", "main.go", 8, 5),
+		at(4, "textDocument/completion", "api/api.go", 14, 13),
+		at(5, "textDocument/completion", "api/api.go", 15, 28),
+		at(6, "textDocument/completion", "api/api.go", 15, 18),
+		at(7, "textDocument/completion", "api/api.go", 13, 12),
+		at(8, "textDocument/completion", "api/api.go", 10, 5),
+	)...)
+
+	var items []completionItem
+
+	result(t, messages, 2, &items)
+	assert.Contains(t, labels(items), "@Summary")
+	assert.Contains(t, labels(items), "@Router")
+	assert.NotContains(t, labels(items), "@title")
+	assert.Equal(t, textRange{Start: position{Line: 12, Character: 3}, End: position{Line: 12, Character: 6}}, items[0].TextEdit.Range)
+
+	result(t, messages, 3, &items)
+	assert.Contains(t, labels(items), "@title")
+	assert.Contains(t, labels(items), "@Summary")
+
+	result(t, messages, 4, &items)
+	assert.Equal(t, paramLocations, labels(items))
+
+	result(t, messages, 5, &items)
+	assert.Equal(t, []string{"model.User"}, labels(items))
+	assert.Equal(t, "github.com/swaggo/swag/testdata/lsp/model", items[0].Detail)
+	assert.Equal(t, textRange{Start: position{Line: 15, Character: 25}, End: position{Line: 15, Character: 28}}, items[0].TextEdit.Range)
+
+	result(t, messages, 6, &items)
+	assert.Equal(t, responseDataTypes, labels(items))
+
+	result(t, messages, 7, &items)
+	assert.Contains(t, labels(items), "json")
+	assert.Contains(t, labels(items), "xml")
+
+	result(t, messages, 8, &items)
+	assert.Empty(t, items)
+}
+
+func TestServer_HoverAndDefinition(t *testing.T) {
+	messages := session(t, append(initialize(t),
+		open(t, "api/api.go"),
+		at(2, "textDocument/hover", "api/api.go", 15, 28),
+		at(3, "textDocument/definition", "api/api.go", 15, 28),
+		at(4, "textDocument/hover", "api/api.go", 12, 15),
+	)...)
+
+	var h *hover
+
+	result(t, messages, 2, &h)
+	require.NotNil(t, h)
+	assert.Equal(t, "markdown", h.Contents.Kind)
+	assert.Contains(t, h.Contents.Value, "**model.User**")
+	assert.Contains(t, h.Contents.Value, `"type": "object"`)
+	assert.Contains(t, h.Contents.Value, `"name"`)
+	assert.Equal(t, &textRange{Start: position{Line: 15, Character: 25}, End: position{Line: 15, Character: 35}}, h.Range)
+
+	var loc *location
+
+	result(t, messages, 3, &loc)
+	require.NotNil(t, loc)
+	assert.Equal(t, uri("model/model.go"), loc.URI)
+	assert.Equal(t, textRange{Start: position{Line: 3, Character: 5}, End: position{Line: 3, Character: 9}}, loc.Range)
+
+	h = nil
+	result(t, messages, 4, &h)
+	assert.Nil(t, h)
+}
@@ -0,0 +1,232 @@
+package lsp
+
+import (
+	"go/ast"
+	goparser "go/parser"
+	"go/token"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/swaggo/swag"
+)
+
+// document is a file opened in the editor, whose text may differ from the saved one.
+type document struct {
+	path string
+	text string
+}
+
+// lines returns the lines of the text of the document.
+func (doc *document) lines() []string {
+	return strings.Split(doc.text, "\n")
+}
+
+// parse returns the syntax tree of the text of the document, which may have errors.
+func (doc *document) parse() (*token.FileSet, *ast.File) {
+	fileSet := token.NewFileSet()
+	astFile, _ := goparser.ParseFile(fileSet, doc.path, doc.text, goparser.ParseComments)
+
+	return fileSet, astFile
+}
+
+// load parses the workspace from the saved files.
+func (s *Server) load() error {
+	parser, err := s.config.Parse(swag.SetDebugger(quietDebugger{}))
+	if parser == nil {
+		return err
+	}
+
+	s.parser = parser
+
+	if _, ok := err.(swag.Diagnostics); ok {
+		return nil
+	}
+
+	return err
+}
+
+// workspaceFile returns the syntax tree of a file of the workspace, nil if it was not
+// parsed.
+func (s *Server) workspaceFile(path string) *ast.File {
+	var astFile *ast.File
+
+	_ = s.parser.Packages().RangeFiles(func(info *swag.AstFileInfo) error {
+		if absPath(info.Path) == path {
+			astFile = info.File
+		}
+
+		return nil
+	})
+
+	return astFile
+}
+
+// publishWorkspaceDiagnostics publishes the diagnostics of the parsing of the workspace,
+// clearing the ones of the files which have none anymore.
+func (s *Server) publishWorkspaceDiagnostics() error {
+	byPath := make(map[string]swag.Diagnostics)
+
+	for _, diagnostic := range s.parser.Diagnostics() {
+		path := absPath(diagnostic.File)
+		byPath[path] = append(byPath[path], diagnostic)
+	}
+
+	for path := range s.published {
+		if _, ok := byPath[path]; !ok {
+			byPath[path] = nil
+		}
+	}
+
+	for path, diagnostics := range byPath {
+		err := s.publishDiagnostics(path, diagnostics)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishDocumentDiagnostics publishes the diagnostics of the operations of a document
+// being edited.
+func (s *Server) publishDocumentDiagnostics(doc *document) error {
+	if s.parser == nil {
+		return nil
+	}
+
+	fileSet, astFile := doc.parse()
+	if astFile == nil {
+		return nil
+	}
+
+	return s.publishDiagnostics(doc.path, s.parser.CheckOperations(fileSet, astFile))
+}
+
+func (s *Server) publishDiagnostics(path string, diagnostics swag.Diagnostics) error {
+	lines := s.fileLines(path)
+
+	params := publishDiagnosticsParams{URI: pathToURI(path), Diagnostics: []diagnostic{}}
+
+	for _, d := range diagnostics {
+		severity := errorSeverity
+		if d.Severity == swag.SeverityWarning {
+			severity = warningSeverity
+		}
+
+		params.Diagnostics = append(params.Diagnostics, diagnostic{
+			Range:    lineRange(lines, d.Line, d.Column),
+			Severity: severity,
+			Code:     d.Code,
+			Source:   "swag",
+			Message:  d.Message,
+		})
+	}
+
+	if len(diagnostics) > 0 {
+		s.published[path] = true
+	} else {
+		delete(s.published, path)
+	}
+
+	return s.notify("textDocument/publishDiagnostics", params)
+}
+
+// fileLines returns the lines of the file, from its open document if any.
+func (s *Server) fileLines(path string) []string {
+	if doc, ok := s.documents[path]; ok {
+		return doc.lines()
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	return strings.Split(string(b), "\n")
+}
+
+// lineRange returns the range from the 1-based line and byte column to the end of the
+// line.
+func lineRange(lines []string, line, column int) textRange {
+	if line < 1 {
+		return textRange{}
+	}
+
+	start := position{Line: line - 1}
+	end := start
+
+	if line <= len(lines) {
+		text := strings.TrimRight(lines[line-1], "\r")
+		if column > 0 {
+			start.Character = utf16Len(text[:min(column-1, len(text))])
+		}
+
+		end.Character = utf16Len(text)
+	}
+
+	return textRange{Start: start, End: end}
+}
+
+// utf16Len returns the length of the text in UTF-16 code units, the unit of the
+// characters of positions.
+func utf16Len(text string) int {
+	n := 0
+
+	for _, r := range text {
+		n += utf16.RuneLen(r)
+	}
+
+	return n
+}
+
+// byteOffset returns the byte offset in the text of a character offset in UTF-16 code
+// units.
+func byteOffset(text string, character int) int {
+	n := 0
+
+	for i, r := range text {
+		if n >= character {
+			return i
+		}
+
+		if r == utf8.RuneError {
+			n++
+		} else {
+			n += utf16.RuneLen(r)
+		}
+	}
+
+	return len(text)
+}
+
+// uriToPath returns the absolute path of a file URI.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return absPath(uri)
+	}
+
+	return absPath(filepath.FromSlash(u.Path))
+}
+
+// pathToURI returns the file URI of a path.
+func pathToURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(absPath(path))}).String()
+}
+
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	return abs
+}
+
+type quietDebugger struct{}
+
+func (quietDebugger) Printf(string, ...any) {}
@@ -0,0 +1,764 @@
+package swag
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// Kinds of MergeConflict.
+const (
+	ConflictOperation          = "operation"
+	ConflictDefinition         = "definition"
+	ConflictParameter          = "parameter"
+	ConflictResponse           = "response"
+	ConflictSecurityDefinition = "securityDefinition"
+	ConflictTag                = "tag"
+	ConflictExtension          = "extension"
+)
+
+// MergeSource is a document to merge with the others.
+type MergeSource struct {
+	// Name identifies the source in conflicts, and namespaces its conflicting definitions.
+	Name string
+	// Prefix is prepended to the paths of the source, after its base path.
+	Prefix string
+	// Swagger is the document. If nil, the instance registered under Name is read.
+	Swagger *spec.Swagger
+}
+
+// MergeConflict describes the sources declaring different things under the same name.
+type MergeConflict struct {
+	Kind    string
+	Name    string
+	Sources []string
+	// Resolution describes how the conflict was resolved, empty if it could not be.
+	Resolution string
+}
+
+// String implements fmt.Stringer.
+func (c MergeConflict) String() string {
+	declared := "declared differently"
+	if c.Kind == ConflictOperation {
+		declared = "declared"
+	}
+
+	text := fmt.Sprintf("%s %q is %s by %s", c.Kind, c.Name, declared, strings.Join(c.Sources, ", "))
+	if c.Resolution == "" {
+		return text
+	}
+
+	return text + ": " + c.Resolution
+}
+
+// MergeResult is the document merged from several sources. It implements Swagger, so it
+// can be registered as an instance itself.
+type MergeResult struct {
+	Swagger   *spec.Swagger
+	Conflicts []MergeConflict
+}
+
+// ReadDoc implements Swagger.
+func (r *MergeResult) ReadDoc() string {
+	b, _ := json.MarshalIndent(r.Swagger, "", "    ")
+
+	return string(b)
+}
+
+// Unresolved returns the conflicts which could not be resolved.
+func (r *MergeResult) Unresolved() []MergeConflict {
+	var conflicts []MergeConflict
+
+	for _, conflict := range r.Conflicts {
+		if conflict.Resolution == "" {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+
+	return conflicts
+}
+
+// Merge combines several documents into one, using info for the merged document, or the
+// info of the first source if nil.
+//
+// Paths are prefixed with the base path and Prefix of their source, the merged document
+// has the base path "/". Definitions, parameters, responses and security definitions
+// declared differently by several sources are renamed to "<source name>.<name>" in each
+// of them, tags are unioned, the first description winning, and so are the names of the
+// document extensions holding objects, like the webhooks of x-webhooks, and the
+// parameters of path items, the first declaration winning. Document wide security,
+// consumes and produces are moved to the operations of their source. Each of these cases
+// is reported as a conflict; an error is returned if the same operation is declared by
+// several sources, along with the result and its unresolved conflicts.
+func Merge(info *spec.Info, sources ...MergeSource) (*MergeResult, error) {
+	if len(sources) == 0 {
+		return nil, errors.New("no source to merge")
+	}
+
+	documents := make([]*spec.Swagger, 0, len(sources))
+	names := make(map[string]bool, len(sources))
+
+	for _, source := range sources {
+		if source.Name == "" {
+			return nil, errors.New("merge sources must be named")
+		}
+
+		if names[source.Name] {
+			return nil, fmt.Errorf("merge source %q is declared twice", source.Name)
+		}
+
+		names[source.Name] = true
+
+		document, err := copyMergeSource(source)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, document)
+	}
+
+	merger := &merger{
+		sources:          sources,
+		documents:        documents,
+		operationSources: make(map[string]string),
+		tagSources:       make(map[string]string),
+		extensionSources: make(map[string]string),
+		parameterSources: make(map[string]string),
+	}
+
+	return merger.merge(info)
+}
+
+// copyMergeSource returns a deep copy of the document of source, so that merging does not
+// change it.
+func copyMergeSource(source MergeSource) (*spec.Swagger, error) {
+	var b []byte
+
+	if source.Swagger != nil {
+		var err error
+		if b, err = json.Marshal(source.Swagger); err != nil {
+			return nil, err
+		}
+	} else {
+		doc, err := ReadDoc(source.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		b = []byte(doc)
+	}
+
+	var document spec.Swagger
+	if err := json.Unmarshal(b, &document); err != nil {
+		return nil, fmt.Errorf("cannot parse the document of %q: %w", source.Name, err)
+	}
+
+	return &document, nil
+}
+
+type merger struct {
+	sources   []MergeSource
+	documents []*spec.Swagger
+	result    MergeResult
+
+	// operationSources, tagSources, extensionSources and parameterSources are the names of
+	// the sources the merged operations, keyed by method and path, tags, extensions, keyed
+	// by extension and name, and path item parameters, keyed by path, location and name,
+	// come from.
+	operationSources map[string]string
+	tagSources       map[string]string
+	extensionSources map[string]string
+	parameterSources map[string]string
+}
+
+func (m *merger) merge(info *spec.Info) (*MergeResult, error) {
+	merged := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Swagger:  "2.0",
+		Info:     info,
+		BasePath: "/",
+		Paths:    &spec.Paths{Paths: make(map[string]spec.PathItem)},
+	}}
+
+	if merged.Info == nil {
+		merged.Info = m.documents[0].Info
+	}
+
+	m.result.Swagger = merged
+
+	// names declared differently are renamed before anything is copied, so that the
+	// references to them can be rewritten
+	rewriters := make([]*mergeRewriter, len(m.documents))
+	for i := range rewriters {
+		rewriters[i] = &mergeRewriter{
+			definitions: make(map[string]string),
+			parameters:  make(map[string]string),
+			responses:   make(map[string]string),
+			security:    make(map[string]string),
+		}
+	}
+
+	// definitions declared alike may still differ once the definitions they refer to are
+	// renamed, so they are compared again until no more definition is renamed
+	for renamed := true; renamed; {
+		renamed = m.renameConflicting(ConflictDefinition, func(i int) map[string]any {
+			return rewrittenValues(m.documents[i].Definitions, rewriters[i].schema)
+		}, func(i int) map[string]string {
+			return rewriters[i].definitions
+		})
+	}
+
+	m.renameConflicting(ConflictParameter, func(i int) map[string]any {
+		return rewrittenValues(m.documents[i].Parameters, rewriters[i].parameter)
+	}, func(i int) map[string]string {
+		return rewriters[i].parameters
+	})
+	m.renameConflicting(ConflictResponse, func(i int) map[string]any {
+		return rewrittenValues(m.documents[i].Responses, rewriters[i].response)
+	}, func(i int) map[string]string {
+		return rewriters[i].responses
+	})
+	m.renameConflicting(ConflictSecurityDefinition, func(i int) map[string]any {
+		return rewrittenValues(m.documents[i].SecurityDefinitions, func(**spec.SecurityScheme) {})
+	}, func(i int) map[string]string {
+		return rewriters[i].security
+	})
+
+	for i, document := range m.documents {
+		m.copyNamed(merged, document, rewriters[i])
+		m.copyPaths(merged, m.sources[i], document, rewriters[i])
+		m.copyTags(merged, m.sources[i].Name, document)
+		m.copyExtensions(merged, m.sources[i].Name, document, rewriters[i])
+	}
+
+	sort.SliceStable(m.result.Conflicts, func(i, j int) bool {
+		if m.result.Conflicts[i].Kind != m.result.Conflicts[j].Kind {
+			return m.result.Conflicts[i].Kind < m.result.Conflicts[j].Kind
+		}
+
+		return m.result.Conflicts[i].Name < m.result.Conflicts[j].Name
+	})
+
+	if unresolved := m.result.Unresolved(); len(unresolved) > 0 {
+		messages := make([]string, 0, len(unresolved))
+		for _, conflict := range unresolved {
+			messages = append(messages, conflict.String())
+		}
+
+		return &m.result, fmt.Errorf("cannot merge documents:\n%s", strings.Join(messages, "\n"))
+	}
+
+	return &m.result, nil
+}
+
+// renameConflicting renames the declarations of kind which are declared differently by
+// several documents, and reports whether any was renamed. declarations returns the
+// declarations of a document, renames the names they are renamed to.
+func (m *merger) renameConflicting(kind string, declarations func(int) map[string]any, renames func(int) map[string]string) bool {
+	sources := make(map[string][]int)
+	encoded := make([]map[string]string, len(m.documents))
+
+	for i := range m.documents {
+		encoded[i] = make(map[string]string)
+
+		for name, value := range declarations(i) {
+			b, _ := json.Marshal(value)
+			encoded[i][name] = string(b)
+			sources[name] = append(sources[name], i)
+		}
+	}
+
+	renamed := false
+
+	for _, name := range sortedKeys(sources) {
+		indexes := sources[name]
+		if _, ok := renames(indexes[0])[name]; ok {
+			continue
+		}
+
+		conflicting := false
+
+		for _, index := range indexes[1:] {
+			if encoded[index][name] != encoded[indexes[0]][name] {
+				conflicting = true
+
+				break
+			}
+		}
+
+		if !conflicting {
+			continue
+		}
+
+		conflict := MergeConflict{Kind: kind, Name: name}
+		newNames := make([]string, 0, len(indexes))
+
+		for _, index := range indexes {
+			newName := m.sources[index].Name + "." + name
+			renames(index)[name] = newName
+
+			conflict.Sources = append(conflict.Sources, m.sources[index].Name)
+			newNames = append(newNames, newName)
+		}
+
+		conflict.Resolution = "renamed to " + strings.Join(newNames, ", ")
+		m.result.Conflicts = append(m.result.Conflicts, conflict)
+		renamed = true
+	}
+
+	return renamed
+}
+
+// copyNamed copies the definitions, parameters, responses and security definitions of
+// document to merged under their new names.
+func (m *merger) copyNamed(merged, document *spec.Swagger, rewriter *mergeRewriter) {
+	for name, definition := range document.Definitions {
+		rewriter.schema(&definition)
+
+		if merged.Definitions == nil {
+			merged.Definitions = make(spec.Definitions)
+		}
+
+		merged.Definitions[rewriter.rename(rewriter.definitions, name)] = definition
+	}
+
+	for name, parameter := range document.Parameters {
+		rewriter.parameter(&parameter)
+
+		if merged.Parameters == nil {
+			merged.Parameters = make(map[string]spec.Parameter)
+		}
+
+		merged.Parameters[rewriter.rename(rewriter.parameters, name)] = parameter
+	}
+
+	for name, response := range document.Responses {
+		rewriter.response(&response)
+
+		if merged.Responses == nil {
+			merged.Responses = make(map[string]spec.Response)
+		}
+
+		merged.Responses[rewriter.rename(rewriter.responses, name)] = response
+	}
+
+	for name, scheme := range document.SecurityDefinitions {
+		if merged.SecurityDefinitions == nil {
+			merged.SecurityDefinitions = make(spec.SecurityDefinitions)
+		}
+
+		merged.SecurityDefinitions[rewriter.rename(rewriter.security, name)] = scheme
+	}
+}
+
+func (m *merger) copyPaths(merged *spec.Swagger, source MergeSource, document *spec.Swagger, rewriter *mergeRewriter) {
+	if document.Paths == nil {
+		return
+	}
+
+	for _, name := range sortedKeys(document.Paths.Paths) {
+		item := document.Paths.Paths[name]
+		fullPath := mergedPath(source.Prefix, document.BasePath, name)
+
+		mergedItem := merged.Paths.Paths[fullPath]
+
+		for i := range item.Parameters {
+			rewriter.parameter(&item.Parameters[i])
+			m.addPathParameter(&mergedItem, source.Name, fullPath, item.Parameters[i])
+		}
+
+		for _, method := range []struct {
+			name   string
+			source *spec.Operation
+			target **spec.Operation
+		}{
+			{http.MethodGet, item.Get, &mergedItem.Get},
+			{http.MethodPut, item.Put, &mergedItem.Put},
+			{http.MethodPost, item.Post, &mergedItem.Post},
+			{http.MethodDelete, item.Delete, &mergedItem.Delete},
+			{http.MethodOptions, item.Options, &mergedItem.Options},
+			{http.MethodHead, item.Head, &mergedItem.Head},
+			{http.MethodPatch, item.Patch, &mergedItem.Patch},
+		} {
+			if method.source == nil {
+				continue
+			}
+
+			key := method.name + " " + fullPath
+
+			if *method.target != nil {
+				m.result.Conflicts = append(m.result.Conflicts, MergeConflict{
+					Kind:    ConflictOperation,
+					Name:    key,
+					Sources: []string{m.operationSources[key], source.Name},
+				})
+
+				continue
+			}
+
+			m.inheritDocumentProperties(method.source, document)
+			rewriter.operation(method.source)
+
+			*method.target = method.source
+			m.operationSources[key] = source.Name
+		}
+
+		merged.Paths.Paths[fullPath] = mergedItem
+	}
+}
+
+// addPathParameter adds a parameter to a merged path item, unless it holds a parameter
+// with the same location and name, or the same reference.
+func (m *merger) addPathParameter(item *spec.PathItem, sourceName, fullPath string, parameter spec.Parameter) {
+	key := fullPath + " " + parameter.In + " " + parameter.Name
+	if parameter.Name == "" {
+		key = fullPath + " " + parameter.Ref.String()
+	}
+
+	for _, existing := range item.Parameters {
+		if existing.In != parameter.In || existing.Name != parameter.Name || existing.Ref.String() != parameter.Ref.String() {
+			continue
+		}
+
+		if !jsonEqual(existing, parameter) {
+			first := m.parameterSources[key]
+
+			m.result.Conflicts = append(m.result.Conflicts, MergeConflict{
+				Kind:       ConflictParameter,
+				Name:       key,
+				Sources:    []string{first, sourceName},
+				Resolution: "kept the one of " + first,
+			})
+		}
+
+		return
+	}
+
+	item.Parameters = append(item.Parameters, parameter)
+	m.parameterSources[key] = sourceName
+}
+
+// inheritDocumentProperties sets the document wide security, consumes and produces on
+// operation, as they cannot be kept document wide in the merged document.
+func (m *merger) inheritDocumentProperties(operation *spec.Operation, document *spec.Swagger) {
+	if operation.Security == nil && len(document.Security) > 0 {
+		operation.Security = document.Security
+	}
+
+	if len(operation.Consumes) == 0 {
+		operation.Consumes = document.Consumes
+	}
+
+	if len(operation.Produces) == 0 {
+		operation.Produces = document.Produces
+	}
+
+	if len(operation.Schemes) == 0 {
+		operation.Schemes = document.Schemes
+	}
+}
+
+func (m *merger) copyTags(merged *spec.Swagger, sourceName string, document *spec.Swagger) {
+	for _, tag := range document.Tags {
+		index := -1
+
+		for i := range merged.Tags {
+			if merged.Tags[i].Name == tag.Name {
+				index = i
+
+				break
+			}
+		}
+
+		if index < 0 {
+			merged.Tags = append(merged.Tags, tag)
+			m.tagSources[tag.Name] = sourceName
+
+			continue
+		}
+
+		existing := merged.Tags[index]
+		if existing.Description == tag.Description && jsonEqual(existing.ExternalDocs, tag.ExternalDocs) {
+			continue
+		}
+
+		first := m.tagSources[tag.Name]
+
+		m.result.Conflicts = append(m.result.Conflicts, MergeConflict{
+			Kind:       ConflictTag,
+			Name:       tag.Name,
+			Sources:    []string{first, sourceName},
+			Resolution: "kept the description of " + first,
+		})
+	}
+}
+
+// copyExtensions copies the extensions of document to merged. The names of the extensions
+// holding objects are unioned, the first value of a name winning.
+func (m *merger) copyExtensions(merged *spec.Swagger, sourceName string, document *spec.Swagger, rewriter *mergeRewriter) {
+	rewriter.extensions(document.Extensions)
+
+	for _, key := range sortedKeys(document.Extensions) {
+		var value any
+		if !decodeExtension(document.Extensions, key, &value) {
+			continue
+		}
+
+		if merged.Extensions == nil {
+			merged.Extensions = make(spec.Extensions)
+		}
+
+		existing, exists := merged.Extensions[key]
+		object, isObject := value.(map[string]any)
+
+		if !exists {
+			merged.Extensions[key] = value
+			m.extensionSources[key] = sourceName
+
+			for name := range object {
+				m.extensionSources[key+"."+name] = sourceName
+			}
+
+			continue
+		}
+
+		existingObject, wasObject := existing.(map[string]any)
+		if !isObject || !wasObject {
+			if !jsonEqual(existing, value) {
+				m.addExtensionConflict(key, sourceName)
+			}
+
+			continue
+		}
+
+		for _, name := range sortedKeys(object) {
+			if _, ok := existingObject[name]; !ok {
+				existingObject[name] = object[name]
+				m.extensionSources[key+"."+name] = sourceName
+
+				continue
+			}
+
+			if !jsonEqual(existingObject[name], object[name]) {
+				m.addExtensionConflict(key+"."+name, sourceName)
+			}
+		}
+	}
+}
+
+func (m *merger) addExtensionConflict(name, sourceName string) {
+	first := m.extensionSources[name]
+
+	m.result.Conflicts = append(m.result.Conflicts, MergeConflict{
+		Kind:       ConflictExtension,
+		Name:       name,
+		Sources:    []string{first, sourceName},
+		Resolution: "kept the one of " + first,
+	})
+}
+
+// mergeRewriter renames the references of a document to the names its declarations are
+// merged under.
+type mergeRewriter struct {
+	definitions map[string]string
+	parameters  map[string]string
+	responses   map[string]string
+	security    map[string]string
+}
+
+func (w *mergeRewriter) rename(renames map[string]string, name string) string {
+	if newName, ok := renames[name]; ok {
+		return newName
+	}
+
+	return name
+}
+
+func (w *mergeRewriter) ref(ref *spec.Ref, prefix string, renames map[string]string) {
+	str := ref.String()
+	if !strings.HasPrefix(str, prefix) {
+		return
+	}
+
+	if newName, ok := renames[str[len(prefix):]]; ok {
+		*ref = spec.MustCreateRef(prefix + newName)
+	}
+}
+
+func (w *mergeRewriter) pathItem(item *spec.PathItem) {
+	for i := range item.Parameters {
+		w.parameter(&item.Parameters[i])
+	}
+
+	for _, method := range routeMethods {
+		if op := *refRouteMethodOp(item, method); op != nil {
+			w.operation(op)
+		}
+	}
+}
+
+func (w *mergeRewriter) operation(operation *spec.Operation) {
+	w.extensions(operation.Extensions)
+
+	for i := range operation.Parameters {
+		w.parameter(&operation.Parameters[i])
+	}
+
+	if operation.Responses != nil {
+		if operation.Responses.Default != nil {
+			w.response(operation.Responses.Default)
+		}
+
+		for code, response := range operation.Responses.StatusCodeResponses {
+			w.response(&response)
+			operation.Responses.StatusCodeResponses[code] = response
+		}
+	}
+
+	for i, requirement := range operation.Security {
+		renamed := make(map[string][]string, len(requirement))
+		for name, scopes := range requirement {
+			renamed[w.rename(w.security, name)] = scopes
+		}
+
+		operation.Security[i] = renamed
+	}
+}
+
+func (w *mergeRewriter) parameter(parameter *spec.Parameter) {
+	w.ref(&parameter.Ref, "#/parameters/", w.parameters)
+	w.schema(parameter.Schema)
+}
+
+func (w *mergeRewriter) response(response *spec.Response) {
+	w.ref(&response.Ref, "#/responses/", w.responses)
+	w.schema(response.Schema)
+	w.extensions(response.Extensions)
+}
+
+// extensions rewrites the references of extensions: the path items of the webhooks of
+// documents and the callbacks of operations, and the $ref of the others, like the bodies
+// and links of responses.
+func (w *mergeRewriter) extensions(extensions spec.Extensions) {
+	for key := range extensions {
+		switch key {
+		case xWebhooksExtension:
+			var webhooks map[string]spec.PathItem
+			if decodeExtension(extensions, key, &webhooks) {
+				for name, item := range webhooks {
+					w.pathItem(&item)
+					webhooks[name] = item
+				}
+
+				extensions[key] = webhooks
+			}
+		case xCallbacksExtension:
+			var callbacks map[string]map[string]spec.PathItem
+			if decodeExtension(extensions, key, &callbacks) {
+				for _, expressions := range callbacks {
+					for expression, item := range expressions {
+						w.pathItem(&item)
+						expressions[expression] = item
+					}
+				}
+
+				extensions[key] = callbacks
+			}
+		default:
+			var value any
+			if decodeExtension(extensions, key, &value) {
+				extensions[key] = w.value(value)
+			}
+		}
+	}
+}
+
+// value rewrites the $ref of a value decoded from JSON.
+func (w *mergeRewriter) value(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, element := range value {
+			if ref, ok := element.(string); ok && key == "$ref" {
+				value[key] = w.refString(ref)
+			} else {
+				value[key] = w.value(element)
+			}
+		}
+	case []any:
+		for i, element := range value {
+			value[i] = w.value(element)
+		}
+	}
+
+	return value
+}
+
+func (w *mergeRewriter) refString(ref string) string {
+	for prefix, renames := range map[string]map[string]string{
+		"#/definitions/": w.definitions,
+		"#/parameters/":  w.parameters,
+		"#/responses/":   w.responses,
+	} {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			return prefix + w.rename(renames, name)
+		}
+	}
+
+	return ref
+}
+
+func (w *mergeRewriter) schema(schema *spec.Schema) {
+	walkSchema(schema, func(schema *spec.Schema) {
+		w.ref(&schema.Ref, "#/definitions/", w.definitions)
+	})
+}
+
+// rewrittenValues returns copies of values with their references rewritten by rewrite.
+func rewrittenValues[T any](values map[string]T, rewrite func(*T)) map[string]any {
+	result := make(map[string]any, len(values))
+
+	for name, value := range values {
+		var copied T
+
+		b, _ := json.Marshal(value)
+		_ = json.Unmarshal(b, &copied)
+
+		rewrite(&copied)
+		result[name] = copied
+	}
+
+	return result
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// mergedPath joins the prefix and base path of a source with one of its paths.
+func mergedPath(prefix, basePath, name string) string {
+	joined := path.Join("/", prefix, basePath, name)
+	if strings.HasSuffix(name, "/") && joined != "/" {
+		joined += "/"
+	}
+
+	return joined
+}
+
+func jsonEqual(a, b any) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+
+	return string(x) == string(y)
+}
@@ -0,0 +1,257 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const usersDoc = `{
+    "swagger": "2.0",
+    "info": {"title": "Users", "version": "1.0"},
+    "basePath": "/api/v1",
+    "consumes": ["application/json"],
+    "security": [{"ApiKey": []}],
+    "tags": [{"name": "users", "description": "User operations"}, {"name": "common", "description": "Shared"}],
+    "paths": {
+        "/users": {
+            "get": {
+                "tags": ["users"],
+                "responses": {
+                    "200": {"description": "OK", "schema": {"$ref": "#/definitions/model.Page"}},
+                    "500": {"description": "Error", "schema": {"$ref": "#/definitions/model.Error"}}
+                }
+            }
+        },
+        "/health": {
+            "get": {"security": [], "responses": {"200": {"description": "OK"}}}
+        }
+    },
+    "definitions": {
+        "model.Page": {"type": "object", "properties": {"items": {"type": "array", "items": {"$ref": "#/definitions/model.Item"}}}},
+        "model.Item": {"type": "object", "properties": {"name": {"type": "string"}}},
+        "model.Error": {"type": "object", "properties": {"message": {"type": "string"}}}
+    },
+    "securityDefinitions": {
+        "ApiKey": {"type": "apiKey", "name": "X-API-KEY", "in": "header"}
+    }
+}`
+
+const ordersDoc = `{
+    "swagger": "2.0",
+    "info": {"title": "Orders", "version": "1.0"},
+    "basePath": "/",
+    "tags": [{"name": "orders"}, {"name": "common", "description": "Common"}],
+    "paths": {
+        "/orders": {
+            "get": {
+                "tags": ["orders"],
+                "security": [{"ApiKey": []}],
+                "responses": {
+                    "200": {"description": "OK", "schema": {"$ref": "#/definitions/model.Page"}},
+                    "500": {"description": "Error", "schema": {"$ref": "#/definitions/model.Error"}}
+                }
+            }
+        }
+    },
+    "definitions": {
+        "model.Page": {"type": "object", "properties": {"items": {"type": "array", "items": {"$ref": "#/definitions/model.Item"}}}},
+        "model.Item": {"type": "object", "properties": {"id": {"type": "integer"}}},
+        "model.Error": {"type": "object", "properties": {"message": {"type": "string"}}}
+    },
+    "securityDefinitions": {
+        "ApiKey": {"type": "apiKey", "name": "Authorization", "in": "header"}
+    }
+}`
+
+func parseTestDoc(t *testing.T, doc string) *spec.Swagger {
+	t.Helper()
+
+	var swagger spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(doc), &swagger))
+
+	return &swagger
+}
+
+func TestMerge(t *testing.T) {
+	setup()
+	Register("orders", &Spec{SwaggerTemplate: ordersDoc})
+
+	users := parseTestDoc(t, usersDoc)
+
+	result, err := Merge(&spec.Info{InfoProps: spec.InfoProps{Title: "Gateway", Version: "2.0"}},
+		MergeSource{Name: "users", Prefix: "/users-service", Swagger: users},
+		MergeSource{Name: "orders", Prefix: "orders"},
+	)
+	require.NoError(t, err)
+
+	merged := result.Swagger
+	assert.Equal(t, "Gateway", merged.Info.Title)
+	assert.Equal(t, "/", merged.BasePath)
+	assert.ElementsMatch(t, []string{"/users-service/api/v1/users", "/users-service/api/v1/health", "/orders/orders"}, sortedKeys(merged.Paths.Paths))
+
+	// definitions which differ, directly or through their references, are namespaced
+	assert.ElementsMatch(t, []string{"model.Error", "users.model.Item", "orders.model.Item", "users.model.Page", "orders.model.Page"}, sortedKeys(merged.Definitions))
+	assert.Equal(t, "#/definitions/users.model.Item", merged.Definitions["users.model.Page"].Properties["items"].Items.Schema.Ref.String())
+
+	getUsers := merged.Paths.Paths["/users-service/api/v1/users"].Get
+	assert.Equal(t, "#/definitions/users.model.Page", getUsers.Responses.StatusCodeResponses[200].Schema.Ref.String())
+	assert.Equal(t, "#/definitions/model.Error", getUsers.Responses.StatusCodeResponses[500].Schema.Ref.String())
+	assert.Equal(t, []string{"application/json"}, getUsers.Consumes)
+	assert.Equal(t, []map[string][]string{{"users.ApiKey": {}}}, getUsers.Security)
+	assert.Empty(t, merged.Paths.Paths["/users-service/api/v1/health"].Get.Security)
+
+	getOrders := merged.Paths.Paths["/orders/orders"].Get
+	assert.Equal(t, "#/definitions/orders.model.Page", getOrders.Responses.StatusCodeResponses[200].Schema.Ref.String())
+	assert.Equal(t, []map[string][]string{{"orders.ApiKey": {}}}, getOrders.Security)
+	assert.Equal(t, "Authorization", merged.SecurityDefinitions["orders.ApiKey"].Name)
+
+	tags := make([]string, 0, len(merged.Tags))
+	for _, tag := range merged.Tags {
+		tags = append(tags, tag.Name+":"+tag.Description)
+	}
+
+	assert.Equal(t, []string{"users:User operations", "common:Shared", "orders:"}, tags)
+
+	assert.Equal(t, []MergeConflict{
+		{Kind: ConflictDefinition, Name: "model.Item", Sources: []string{"users", "orders"}, Resolution: "renamed to users.model.Item, orders.model.Item"},
+		{Kind: ConflictDefinition, Name: "model.Page", Sources: []string{"users", "orders"}, Resolution: "renamed to users.model.Page, orders.model.Page"},
+		{Kind: ConflictSecurityDefinition, Name: "ApiKey", Sources: []string{"users", "orders"}, Resolution: "renamed to users.ApiKey, orders.ApiKey"},
+		{Kind: ConflictTag, Name: "common", Sources: []string{"users", "orders"}, Resolution: "kept the description of users"},
+	}, result.Conflicts)
+
+	// the sources are left unchanged
+	assert.Equal(t, "#/definitions/model.Page", users.Paths.Paths["/users"].Get.Responses.StatusCodeResponses[200].Schema.Ref.String())
+
+	var doc spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(result.ReadDoc()), &doc))
+	assert.Equal(t, "Gateway", doc.Info.Title)
+}
+
+const itemsDoc = `{
+    "swagger": "2.0",
+    "info": {"title": "Items", "version": "1.0"},
+    "paths": {
+        "/items/{id}": {
+            "parameters": [
+                {"name": "id", "in": "path", "required": true, "type": "string"},
+                {"name": "format", "in": "query", "type": "string"}
+            ],
+            "%s": {
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {"$ref": "#/definitions/Item"},
+                        "x-content": {"text/csv": {"schema": {"$ref": "#/definitions/Item"}}}
+                    }
+                },
+                "x-callbacks": {
+                    "onChange": {
+                        "{$request.body#/url}": {
+                            "post": {
+                                "parameters": [{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Item"}}],
+                                "responses": {"200": {"description": "OK"}}
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "Item": {"type": "object", "properties": {"%s": {"type": "string"}}}
+    },
+    "x-webhooks": {
+        "%s": {
+            "post": {
+                "parameters": [{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Item"}}],
+                "responses": {"200": {"description": "OK"}}
+            }
+        },
+        "itemChanged": {
+            "post": {
+                "parameters": [{"name": "body", "in": "body", "schema": {"$ref": "#/definitions/Item"}}],
+                "responses": {"200": {"description": "OK"}}
+            }
+        }
+    },
+    "x-logo": "%s"
+}`
+
+func TestMerge_Extensions(t *testing.T) {
+	a := parseTestDoc(t, fmt.Sprintf(itemsDoc, "get", "name", "itemCreated", "a.png"))
+	b := parseTestDoc(t, fmt.Sprintf(itemsDoc, "put", "id", "itemDeleted", "b.png"))
+	b.Paths.Paths["/items/{id}"].Parameters[1].Type = "integer"
+
+	result, err := Merge(nil, MergeSource{Name: "a", Swagger: a}, MergeSource{Name: "b", Swagger: b})
+	require.NoError(t, err)
+
+	merged := result.Swagger
+	item := merged.Paths.Paths["/items/{id}"]
+
+	// the parameters of the path item are declared once
+	require.Len(t, item.Parameters, 2)
+	assert.Equal(t, "string", item.Parameters[1].Type)
+
+	// the references of the extensions are renamed with their definitions
+	for name, operation := range map[string]*spec.Operation{"a": item.Get, "b": item.Put} {
+		ref := "#/definitions/" + name + ".Item"
+
+		var content map[string]responseMediaType
+		require.True(t, decodeExtension(operation.Responses.StatusCodeResponses[200].Extensions, xContentExtension, &content))
+		assert.Equal(t, ref, content["text/csv"].Schema.Ref.String())
+
+		callbacks := webhookPathItems(operation.Extensions)
+		require.Len(t, callbacks, 1)
+		assert.Equal(t, ref, callbacks[0].Post.Parameters[0].Schema.Ref.String())
+	}
+
+	var webhooks map[string]spec.PathItem
+	require.True(t, decodeExtension(merged.Extensions, xWebhooksExtension, &webhooks))
+	assert.ElementsMatch(t, []string{"itemChanged", "itemCreated", "itemDeleted"}, sortedKeys(webhooks))
+	assert.Equal(t, "#/definitions/a.Item", webhooks["itemChanged"].Post.Parameters[0].Schema.Ref.String())
+	assert.Equal(t, "#/definitions/a.Item", webhooks["itemCreated"].Post.Parameters[0].Schema.Ref.String())
+	assert.Equal(t, "#/definitions/b.Item", webhooks["itemDeleted"].Post.Parameters[0].Schema.Ref.String())
+	assert.Equal(t, "a.png", merged.Extensions["x-logo"])
+	assert.Empty(t, PruneDefinitions(merged))
+
+	assert.Equal(t, []MergeConflict{
+		{Kind: ConflictDefinition, Name: "Item", Sources: []string{"a", "b"}, Resolution: "renamed to a.Item, b.Item"},
+		{Kind: ConflictExtension, Name: "x-logo", Sources: []string{"a", "b"}, Resolution: "kept the one of a"},
+		{Kind: ConflictExtension, Name: "x-webhooks.itemChanged", Sources: []string{"a", "b"}, Resolution: "kept the one of a"},
+		{Kind: ConflictParameter, Name: "/items/{id} query format", Sources: []string{"a", "b"}, Resolution: "kept the one of a"},
+	}, result.Conflicts)
+}
+
+func TestMerge_OperationConflict(t *testing.T) {
+	result, err := Merge(nil,
+		MergeSource{Name: "a", Swagger: parseTestDoc(t, ordersDoc)},
+		MergeSource{Name: "b", Swagger: parseTestDoc(t, ordersDoc)},
+	)
+	require.Error(t, err)
+	assert.Equal(t, "cannot merge documents:\noperation \"GET /orders\" is declared by a, b", err.Error())
+	require.NotNil(t, result)
+	assert.Equal(t, "Orders", result.Swagger.Info.Title)
+	assert.Empty(t, result.Conflicts[:len(result.Conflicts)-1])
+	assert.Len(t, result.Unresolved(), 1)
+}
+
+func TestMerge_Errors(t *testing.T) {
+	setup()
+
+	_, err := Merge(nil)
+	assert.EqualError(t, err, "no source to merge")
+
+	_, err = Merge(nil, MergeSource{Swagger: &spec.Swagger{}})
+	assert.EqualError(t, err, "merge sources must be named")
+
+	_, err = Merge(nil, MergeSource{Name: "a", Swagger: &spec.Swagger{}}, MergeSource{Name: "a", Swagger: &spec.Swagger{}})
+	assert.EqualError(t, err, `merge source "a" is declared twice`)
+
+	_, err = Merge(nil, MergeSource{Name: "missing"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,341 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// definitionNameData is the data the definition name template is executed with.
+type definitionNameData struct {
+	// Package is the name of the package of the type, like model
+	Package string
+
+	// Type is the name of the type, prefixed with the name of the function declaring it
+	// when it is declared in one, like User or GetUserResponse
+	Type string
+
+	// TypeArgs are the type arguments of an instantiated generic type, like UserList for
+	// Page[[]model.User], empty for other types
+	TypeArgs string
+
+	// ImportPath is the import path of the package of the type, like github.com/x/api/model
+	ImportPath string
+}
+
+// DefinitionRename is a definition named after the import path of its type, because the
+// name given by the definition name template is the one of other types too.
+type DefinitionRename struct {
+	// Name is the name given by the template
+	Name string
+
+	// Rename is the name of the definition
+	Rename string
+
+	// TypeSpec is the type of the definition
+	TypeSpec *TypeSpecDef
+}
+
+// definitionNamer names the definitions with the definition name template. The types
+// sharing a name are all named after the import path of their package, so that the
+// names do not depend on the order the types are parsed in, and the instantiated generic
+// types after the ones named before them.
+type definitionNamer struct {
+	template *template.Template
+
+	// names are the names of the types, owners the types of the names
+	names  map[*TypeSpecDef]string
+	owners map[string]*TypeSpecDef
+
+	renames []DefinitionRename
+}
+
+// newDefinitionNamer parses the definition name template.
+func newDefinitionNamer(text string) (*definitionNamer, error) {
+	tmpl, err := template.New("definition name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid definition name template: %w", err)
+	}
+
+	return &definitionNamer{
+		template: tmpl,
+		names:    make(map[*TypeSpecDef]string),
+		owners:   make(map[string]*TypeSpecDef),
+	}, nil
+}
+
+// nameTypes names the types which are not generic, renaming the ones sharing a name.
+// The names given by @name are kept as they are.
+func (namer *definitionNamer) nameTypes(typeSpecs []*TypeSpecDef) error {
+	sort.Slice(typeSpecs, func(i, j int) bool {
+		return typeSpecs[i].TypeName() < typeSpecs[j].TypeName()
+	})
+
+	byName := make(map[string][]*TypeSpecDef)
+
+	var names []string
+
+	for _, typeSpec := range typeSpecs {
+		if typeSpec.TypeSpec.TypeParams != nil && len(typeSpec.TypeSpec.TypeParams.List) > 0 {
+			continue
+		}
+
+		if alias := typeSpec.Alias(); alias != "" {
+			namer.names[typeSpec] = alias
+			namer.owners[alias] = typeSpec
+
+			continue
+		}
+
+		name, err := namer.execute(typeSpec, "")
+		if err != nil {
+			return err
+		}
+
+		if _, ok := byName[name]; !ok {
+			names = append(names, name)
+		}
+
+		byName[name] = append(byName[name], typeSpec)
+	}
+
+	// the names shared by several types are taken by none of them
+	for _, name := range names {
+		if _, ok := namer.owners[name]; !ok && len(byName[name]) == 1 {
+			namer.names[byName[name][0]] = name
+			namer.owners[name] = byName[name][0]
+		}
+	}
+
+	for _, name := range names {
+		if namer.owners[name] != byName[name][0] {
+			namer.rename(byName[name], name)
+		}
+	}
+
+	return nil
+}
+
+// name returns the name of the definition of a type, naming it when it is an
+// instantiated generic type named for the first time.
+func (namer *definitionNamer) name(typeSpec *TypeSpecDef) (string, error) {
+	if name, ok := namer.names[typeSpec]; ok {
+		return name, nil
+	}
+
+	name, err := namer.execute(typeSpec, "")
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := namer.owners[name]; !ok {
+		namer.names[typeSpec] = name
+		namer.owners[name] = typeSpec
+
+		return name, nil
+	}
+
+	namer.rename([]*TypeSpecDef{typeSpec}, name)
+
+	return namer.names[typeSpec], nil
+}
+
+// rename names types whose name is taken after the last elements of the import path of
+// their package, with as many elements as needed for their names to be unique, and after
+// a number when they are not enough. The elements replace the package in the template,
+// like V1Model.User for User of v1/model with {{.Package}}.{{.Type}}, or prefix the name,
+// like V1ModelUser with {{.Type}}.
+func (namer *definitionNamer) rename(typeSpecs []*TypeSpecDef, name string) {
+	elements := make([][]string, len(typeSpecs))
+	maxElements := 0
+
+	for i, typeSpec := range typeSpecs {
+		importPath := typeSpec.PkgPath
+		if typeSpec.origin != nil {
+			importPath = typeSpec.origin.PkgPath
+		}
+
+		elements[i] = strings.FieldsFunc(importPath, func(r rune) bool {
+			return r == '/' || r == '\\'
+		})
+		maxElements = max(maxElements, len(elements[i]))
+	}
+
+	renames := make([]string, len(typeSpecs))
+
+	for n := 1; n <= maxElements; n++ {
+		unique := make(map[string]struct{}, len(typeSpecs))
+
+		for i, typeSpec := range typeSpecs {
+			qualifier := pascalCase(strings.Join(elements[i][max(len(elements[i])-n, 0):], "_"))
+
+			renames[i] = qualifier + name
+			if rename, err := namer.execute(typeSpec, qualifier); err == nil && rename != name {
+				renames[i] = rename
+			}
+
+			unique[renames[i]] = struct{}{}
+		}
+
+		if len(unique) == len(typeSpecs) && namer.isFree(renames) {
+			namer.setRenames(typeSpecs, name, renames)
+
+			return
+		}
+	}
+
+	next := 2
+
+	for i := range typeSpecs {
+		for {
+			renames[i] = name + strconv.Itoa(next)
+			next++
+
+			if namer.isFree(renames[i : i+1]) {
+				break
+			}
+		}
+	}
+
+	namer.setRenames(typeSpecs, name, renames)
+}
+
+func (namer *definitionNamer) isFree(names []string) bool {
+	for _, name := range names {
+		if _, ok := namer.owners[name]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (namer *definitionNamer) setRenames(typeSpecs []*TypeSpecDef, name string, renames []string) {
+	for i, typeSpec := range typeSpecs {
+		namer.names[typeSpec] = renames[i]
+		namer.owners[renames[i]] = typeSpec
+		namer.renames = append(namer.renames, DefinitionRename{Name: name, Rename: renames[i], TypeSpec: typeSpec})
+	}
+}
+
+// execute returns the name given by the template to a type, with the package replaced by
+// pkg when it is not empty, and the one of its type argument to the slice or map of a
+// type argument, like StringUserMap.
+func (namer *definitionNamer) execute(typeSpec *TypeSpecDef, pkg string) (string, error) {
+	if typeSpec.origin == nil && len(typeSpec.typeArgs) == 1 {
+		return typeArgName(typeSpec.typeArgs[0]), nil
+	}
+
+	var data definitionNameData
+
+	declared := typeSpec
+	if typeSpec.origin != nil {
+		declared = typeSpec.origin
+
+		for _, typeArg := range typeSpec.typeArgs {
+			data.TypeArgs += typeArgName(typeArg)
+		}
+	}
+
+	data.Type = declared.Name()
+	if parentFunc, ok := declared.ParentSpec.(*ast.FuncDecl); ok && parentFunc != nil {
+		data.Type = parentFunc.Name.Name + data.Type
+	}
+
+	if declared.File != nil {
+		data.Package = declared.File.Name.Name
+	}
+
+	if pkg != "" {
+		data.Package = pkg
+	}
+
+	data.ImportPath = declared.PkgPath
+
+	var name strings.Builder
+
+	err := namer.template.Execute(&name, data)
+	if err != nil {
+		return "", fmt.Errorf("cannot name the definition of %s: %w", declared.FullPath(), err)
+	}
+
+	if strings.TrimSpace(name.String()) == "" {
+		return "", fmt.Errorf("cannot name the definition of %s: the definition name template gives an empty name", declared.FullPath())
+	}
+
+	return name.String(), nil
+}
+
+// typeArgName returns the name of a type argument in the name of an instantiated generic
+// type, without the package qualifiers: User for model.User, UserList for []model.User,
+// StringUserMap for map[string]model.User and PageUser for Page[model.User].
+func typeArgName(typeArg string) string {
+	typeArg = strings.TrimLeft(typeArg, "*")
+
+	if elem, ok := strings.CutPrefix(typeArg, "[]"); ok {
+		return typeArgName(elem) + "List"
+	}
+
+	if rest, ok := strings.CutPrefix(typeArg, "map["); ok {
+		if key, value, found := strings.Cut(rest, "]"); found {
+			return typeArgName(key) + typeArgName(value) + "Map"
+		}
+	}
+
+	if genericName, genericArgs := splitGenericsTypeName(typeArg); genericArgs != nil {
+		name := typeArgName(genericName)
+		for _, genericArg := range genericArgs {
+			name += typeArgName(genericArg)
+		}
+
+		return name
+	}
+
+	if typeArg == "interface{}" || typeArg == ANY {
+		return "Any"
+	}
+
+	return pascalCase(typeArg[strings.LastIndex(typeArg, ".")+1:])
+}
+
+// pascalCase returns the words of a name, split at the characters which are neither
+// letters nor digits, with their first letter in upper case: GithubCom for github.com.
+func pascalCase(name string) string {
+	var result strings.Builder
+
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		result.WriteString(string(runes))
+	}
+
+	return result.String()
+}
+
+// DefinitionRenames returns the definitions of the document named after the import path
+// of their type by the definition name template, sorted by name.
+func (parser *Parser) DefinitionRenames() []DefinitionRename {
+	if parser.definitionNamer == nil {
+		return nil
+	}
+
+	var renames []DefinitionRename
+
+	for _, rename := range parser.definitionNamer.renames {
+		if _, ok := parser.outputSchemas[rename.TypeSpec]; ok {
+			renames = append(renames, rename)
+		}
+	}
+
+	sort.SliceStable(renames, func(i, j int) bool {
+		return renames[i].Rename < renames[j].Rename
+	})
+
+	return renames
+}
@@ -0,0 +1,164 @@
+package swag
+
+import (
+	"encoding/json"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDefinitionNames(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/definition_names"
+	p := New(SetDefinitionNameTemplate("{{.Type}}{{.TypeArgs}}"))
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+
+	var renames []string
+	for _, rename := range p.DefinitionRenames() {
+		renames = append(renames, rename.TypeSpec.FullPath()+" "+rename.Name+" "+rename.Rename)
+	}
+
+	assert.Equal(t, []string{
+		"github.com/swaggo/swag/testdata/definition_names/v1/model.User User V1ModelUser",
+		"github.com/swaggo/swag/testdata/definition_names/v2/model.User User V2ModelUser",
+	}, renames)
+}
+
+func TestParseDefinitionNames_Package(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetDefinitionNameTemplate("{{.Package}}.{{.Type}}{{.TypeArgs}}"))
+	err := p.ParseAPI("testdata/definition_names", mainAPIFile, defaultParseDepth)
+	require.NoError(t, err)
+
+	var names []string
+	for name := range p.swagger.Definitions {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"response.Error", "api.ListUsersV2Filter", "response.PageRoleList", "response.PageStringUserMap",
+		"response.PageUser", "StringUserMap", "UserRole", "V1Model.User", "V2Model.User",
+	}, names)
+}
+
+func TestParseDefinitionNames_InvalidTemplate(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetDefinitionNameTemplate("{{.Type"))
+	err := p.ParseAPI("testdata/definition_names", mainAPIFile, defaultParseDepth)
+	assert.ErrorContains(t, err, "invalid definition name template")
+
+	p = New(SetDefinitionNameTemplate("{{.Name}}"))
+	err = p.ParseAPI("testdata/definition_names", mainAPIFile, defaultParseDepth)
+	assert.ErrorContains(t, err, "cannot name the definition of")
+
+	p = New(SetDefinitionNameTemplate("{{if false}}x{{end}}"))
+	err = p.ParseAPI("testdata/definition_names", mainAPIFile, defaultParseDepth)
+	assert.ErrorContains(t, err, "gives an empty name")
+}
+
+func newNamedTypeSpec(pkgPath, pkgName, name string) *TypeSpecDef {
+	return &TypeSpecDef{
+		PkgPath:  pkgPath,
+		File:     &ast.File{Name: ast.NewIdent(pkgName)},
+		TypeSpec: &ast.TypeSpec{Name: ast.NewIdent(name)},
+	}
+}
+
+func TestDefinitionNamer(t *testing.T) {
+	t.Parallel()
+
+	namer, err := newDefinitionNamer("{{.Type}}")
+	require.NoError(t, err)
+
+	a := newNamedTypeSpec("x/a/model", "model", "User")
+	b := newNamedTypeSpec("x/b/model", "model", "User")
+	c := newNamedTypeSpec("x/c", "c", "Account")
+	aliased := newNamedTypeSpec("x/d", "d", "Person")
+	aliased.TypeSpec.Comment = &ast.CommentGroup{List: []*ast.Comment{{Text: "// @name Account"}}}
+
+	require.NoError(t, namer.nameTypes([]*TypeSpecDef{b, c, aliased, a}))
+
+	for typeSpec, name := range map[*TypeSpecDef]string{a: "AModelUser", b: "BModelUser", c: "CAccount", aliased: "Account"} {
+		got, err := namer.name(typeSpec)
+		assert.NoError(t, err)
+		assert.Equal(t, name, got)
+	}
+
+	// the names do not depend on the order of the types
+	namer, err = newDefinitionNamer("{{.Type}}")
+	require.NoError(t, err)
+	require.NoError(t, namer.nameTypes([]*TypeSpecDef{a, b}))
+	assert.Equal(t, "AModelUser", namer.names[a])
+	assert.Equal(t, "BModelUser", namer.names[b])
+
+	// the instantiated generic types are named after the ones named before them
+	page := newNamedTypeSpec("x/response", "response", "Page")
+	first := &TypeSpecDef{TypeSpec: &ast.TypeSpec{Name: ast.NewIdent("$first")}, origin: page, typeArgs: []string{"a.User"}}
+	second := &TypeSpecDef{TypeSpec: &ast.TypeSpec{Name: ast.NewIdent("$second")}, origin: page, typeArgs: []string{"b.User"}}
+	third := &TypeSpecDef{TypeSpec: &ast.TypeSpec{Name: ast.NewIdent("$third")}, origin: page, typeArgs: []string{"c.User"}}
+
+	name, err := namer.name(first)
+	assert.NoError(t, err)
+	assert.Equal(t, "Page", name)
+
+	name, err = namer.name(second)
+	assert.NoError(t, err)
+	assert.Equal(t, "ResponsePage", name)
+
+	name, err = namer.name(third)
+	assert.NoError(t, err)
+	assert.Equal(t, "XResponsePage", name)
+
+	fourth := &TypeSpecDef{TypeSpec: &ast.TypeSpec{Name: ast.NewIdent("$fourth")}, origin: page, typeArgs: []string{"d.User"}}
+	name, err = namer.name(fourth)
+	assert.NoError(t, err)
+	assert.Equal(t, "Page2", name)
+
+	assert.Len(t, namer.renames, 5)
+}
+
+func TestTypeArgName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"model.User":                    "User",
+		"*model.User":                   "User",
+		"string":                        "String",
+		"int64":                         "Int64",
+		"any":                           "Any",
+		"interface{}":                   "Any",
+		"[]model.User":                  "UserList",
+		"[][]int":                       "IntListList",
+		"map[string]model.User":         "StringUserMap",
+		"map[string][]model.User":       "StringUserListMap",
+		"response.Page[model.User]":     "PageUser",
+		"response.Pair[string,[]int]":   "PairStringIntList",
+		"github_com_x_model.User":       "User",
+		"api.GetUser.Response":          "Response",
+		"response.Page[map[int]string]": "PageIntStringMap",
+	}
+
+	for typeArg, name := range tests {
+		assert.Equal(t, name, typeArgName(typeArg), typeArg)
+	}
+}
+
+func TestPascalCase(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "GithubCom", pascalCase("github.com"))
+	assert.Equal(t, "GoKitV2", pascalCase("go-kit_v2"))
+	assert.Equal(t, "", pascalCase("."))
+}
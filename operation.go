@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	goparser "go/parser"
 	"go/token"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -29,8 +30,19 @@ type RouteProperties struct {
 type Operation struct {
 	parser              *Parser
 	codeExampleFilesDir string
+	// packagePath is the import path of the package declaring the operation
+	packagePath string
+	// templates are the names of the templates the operation uses
+	templates []string
+	// callbacks are the callbacks of the operation, resolved once every webhook is parsed
+	callbacks []operationCallback
+	// position is the position of the comment being parsed, for the diagnostics
+	position token.Position
+	// commentBlock is the block of the comment being parsed, if it ends with the block marker
+	commentBlock string
 	spec.Operation
 	RouterProperties []RouteProperties
+	Webhooks         []WebhookProperties
 	State            string
 }
 
@@ -50,9 +62,40 @@ var mimeTypeAliases = map[string]string{
 	"event-stream":          "text/event-stream",
 }
 
+// MimeTypeAliases returns the media types of the aliases accepted by @Accept and @Produce,
+// like application/json for json.
+func MimeTypeAliases() map[string]string {
+	return maps.Clone(mimeTypeAliases)
+}
+
 var mimeTypePattern = regexp.MustCompile("^[^/]+/[^/]+$")
 var securityPairSepPattern = regexp.MustCompile(`\|\||&&`) // || for compatibility with old version, && for clarity
 
+// operationAttributeNames are the canonical names of the operation attributes.
+var operationAttributeNames = []string{
+	"@Summary", "@Description", "@Description.markdown", "@ID", "@Tags", "@Accept", "@Produce",
+	"@Use", "@Security", "@Param", "@Success", "@Failure", "@Response", "@Header", "@Link",
+	"@Callback", "@Deprecated", "@Router", "@DeprecatedRouter", "@Webhook", "@State",
+	"@Template", "@x-codeSamples",
+}
+
+// IsOperationAttribute reports whether the attribute, like @Success, is understood in the
+// comments of operations, the @x- extensions included.
+func IsOperationAttribute(attribute string) bool {
+	lowerAttribute := strings.ToLower(attribute)
+	if strings.HasPrefix(lowerAttribute, "@x-") {
+		return true
+	}
+
+	for _, name := range operationAttributeNames {
+		if strings.ToLower(name) == lowerAttribute {
+			return true
+		}
+	}
+
+	return false
+}
+
 // NewOperation creates a new Operation with default properties.
 // map[int]Response.
 func NewOperation(parser *Parser, options ...func(*Operation)) *Operation {
@@ -108,7 +151,13 @@ func SetCodeExampleFilesDirectory(directoryPath string) func(*Operation) {
 }
 
 // ParseComment parses comment for given comment string and returns error if error occurs.
+// The comment may be an attribute line joined with the lines of its block.
 func (operation *Operation) ParseComment(comment string, astFile *ast.File) error {
+	comment, operation.commentBlock = cutCommentBlock(comment)
+	defer func() {
+		operation.commentBlock = ""
+	}()
+
 	commentLine := strings.TrimSpace(strings.TrimLeft(comment, "/"))
 	if len(commentLine) == 0 {
 		return nil
@@ -125,7 +174,7 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 	case stateAttr:
 		operation.ParseStateComment(lineRemainder)
 	case descriptionAttr:
-		operation.ParseDescriptionComment(lineRemainder)
+		operation.ParseDescriptionComment(operation.blockDescription(lineRemainder))
 	case descriptionMarkdownAttr:
 		commentInfo, err := getMarkdownForTag(lineRemainder, operation.parser.markdownFileDir)
 		if err != nil {
@@ -149,6 +198,12 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 		return operation.ParseResponseComment(lineRemainder, astFile)
 	case headerAttr:
 		return operation.ParseResponseHeaderComment(lineRemainder, astFile)
+	case linkAttr:
+		return operation.ParseLinkComment(lineRemainder)
+	case webhookAttr:
+		return operation.ParseWebhookComment(lineRemainder)
+	case callbackAttr:
+		return operation.ParseCallbackComment(lineRemainder)
 	case routerAttr:
 		return operation.ParseRouterComment(lineRemainder, false)
 	case deprecatedRouterAttr:
@@ -159,7 +214,16 @@ func (operation *Operation) ParseComment(comment string, astFile *ast.File) erro
 		operation.Deprecate()
 	case xCodeSamplesAttr:
 		return operation.ParseCodeSample(attribute, commentLine, lineRemainder)
+	case useAttr:
+		return operation.ParseUseComment(lineRemainder)
 	default:
+		// The functions of the general API info file are parsed as operations too.
+		err := operation.parser.checkAttribute(operation.position, attribute,
+			append(operationAttributeNames, generalAttributeNames...), operationAttributeNames)
+		if err != nil {
+			return err
+		}
+
 		return operation.ParseMetadata(attribute, lowerAttribute, lineRemainder)
 	}
 
@@ -229,6 +293,9 @@ func (operation *Operation) ParseMetadata(attribute, lowerAttribute, lineRemaind
 	return nil
 }
 
+// refPrefix prefixes the name of a parameter or response defined in the general API info.
+const refPrefix = "$ref:"
+
 var paramPattern = regexp.MustCompile(`(\S+)\s+(\w+)\s+([\S. ]+?)\s+(\w+)\s+"([^"]+)"`)
 
 func findInSlice(arr []string, target string) bool {
@@ -248,6 +315,12 @@ func findInSlice(arr []string, target string) bool {
 //
 // E.g. @Param   some_id     path    int     true        "Some ID".
 func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.File) error {
+	if name, ok := strings.CutPrefix(strings.TrimSpace(commentLine), refPrefix); ok {
+		return operation.parseParamRef(name)
+	}
+
+	commentLine = quoteBlockMarker(commentLine)
+
 	matches := paramPattern.FindStringSubmatch(commentLine)
 	if len(matches) != 6 {
 		return fmt.Errorf("missing required param comment parameters \"%s\"", commentLine)
@@ -283,7 +356,7 @@ func (operation *Operation) ParseParamComment(commentLine string, astFile *ast.F
 
 	requiredText := strings.ToLower(matches[4])
 	required := requiredText == "true" || requiredText == requiredLabel
-	description := strings.Join(strings.Split(matches[5], "\\n"), "\n")
+	description := operation.blockDescription(strings.Join(strings.Split(matches[5], "\\n"), "\n"))
 
 	param := createParameter(paramType, description, name, objectType, refType, format, required, enums, operation.parser.collectionFormatInQuery)
 
@@ -467,6 +540,20 @@ var regexAttributes = map[string]*regexp.Regexp{
 	schemaExampleTag: regexp.MustCompile(`(?i)\s+schemaExample\(.*?\)(?:\s|$)`),
 }
 
+// parseParamRef adds a reference to a parameter defined with @param.define.
+// E.g. @Param $ref:pagination.
+func (operation *Operation) parseParamRef(name string) error {
+	if _, ok := operation.parser.swagger.Parameters[name]; !ok {
+		return fmt.Errorf("parameter %s is not defined, define it with %s", name, paramDefineAttr)
+	}
+
+	operation.Operation.Parameters = append(operation.Operation.Parameters, spec.Parameter{
+		Refable: spec.Refable{Ref: spec.MustCreateRef("#/parameters/" + name)},
+	})
+
+	return nil
+}
+
 func (operation *Operation) parseParamAttribute(comment, objectType, schemaType, paramType string, param *spec.Parameter) error {
 	schemaType = TransToValidSchemeType(schemaType)
 
@@ -1005,6 +1092,26 @@ func (operation *Operation) parseAPIObjectSchema(commentLine, schemaType, refTyp
 
 // ParseResponseComment parses comment for given `response` comment string.
 func (operation *Operation) ParseResponseComment(commentLine string, astFile *ast.File) error {
+	if matches := responseRefPattern.FindStringSubmatch(commentLine); len(matches) == 3 {
+		return operation.parseResponseRef(matches[1], matches[2])
+	}
+
+	// the options precede the block marker
+	commentLine, isBlock := cutBlockMarker(commentLine)
+
+	commentLine, options, err := splitResponseOptions(commentLine)
+	if err != nil {
+		return err
+	}
+
+	if isBlock {
+		commentLine += ` "` + blockMarker + `"`
+	}
+
+	if len(options) > 0 {
+		return operation.parseResponseWithOptions(commentLine, options, astFile)
+	}
+
 	matches := responsePattern.FindStringSubmatch(commentLine)
 	if len(matches) != 5 {
 		err := operation.ParseEmptyResponseComment(commentLine)
@@ -1015,7 +1122,7 @@ func (operation *Operation) ParseResponseComment(commentLine string, astFile *as
 		return err
 	}
 
-	description := strings.Trim(matches[4], "\"")
+	description := operation.blockDescription(strings.Trim(matches[4], "\""))
 
 	schema, err := operation.parseAPIObjectSchema(commentLine, strings.Trim(matches[2], "{}"), strings.TrimSpace(matches[3]), astFile)
 	if err != nil {
@@ -1045,6 +1152,35 @@ func (operation *Operation) ParseResponseComment(commentLine string, astFile *as
 	return nil
 }
 
+var responseRefPattern = regexp.MustCompile(`^([\w,]+)\s+\$ref:(\S+)$`)
+
+// parseResponseRef adds a reference to a response defined with @response.define for the
+// given codes. E.g. @Failure 500 $ref:InternalError.
+func (operation *Operation) parseResponseRef(codes, name string) error {
+	if _, ok := operation.parser.swagger.Responses[name]; !ok {
+		return fmt.Errorf("response %s is not defined, define it with %s", name, responseDefineAttr)
+	}
+
+	ref := spec.Refable{Ref: spec.MustCreateRef("#/responses/" + name)}
+
+	for _, codeStr := range strings.Split(codes, ",") {
+		if strings.EqualFold(codeStr, defaultTag) {
+			operation.DefaultResponse().Refable = ref
+
+			continue
+		}
+
+		code, err := strconv.Atoi(codeStr)
+		if err != nil {
+			return fmt.Errorf("can not parse response comment \"%s $ref:%s\"", codes, name)
+		}
+
+		operation.AddResponse(code, &spec.Response{Refable: ref})
+	}
+
+	return nil
+}
+
 func newHeaderSpec(schemaType, description string) spec.Header {
 	return spec.Header{
 		SimpleSchema: spec.SimpleSchema{
@@ -1135,7 +1271,7 @@ func (operation *Operation) ParseEmptyResponseComment(commentLine string) error
 		return fmt.Errorf("can not parse response comment \"%s\"", commentLine)
 	}
 
-	description := strings.Trim(matches[2], "\"")
+	description := operation.blockDescription(strings.Trim(matches[2], "\""))
 
 	for _, codeStr := range strings.Split(matches[1], ",") {
 		if strings.EqualFold(codeStr, defaultTag) {
@@ -317,6 +317,45 @@ func TestParseResponseFailureCommentWithEmptyResponse(t *testing.T) {
 	assert.Equal(t, expected, string(b))
 }
 
+func TestParseResponseCommentWithRef(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	parser.swagger.Responses = map[string]spec.Response{"InternalError": *spec.NewResponse().WithDescription("Internal error")}
+
+	operation := NewOperation(parser)
+	assert.NoError(t, operation.ParseComment(`@Failure 500,default $ref:InternalError`, nil))
+
+	b, _ := json.MarshalIndent(operation, "", "    ")
+	expected := `{
+    "responses": {
+        "500": {
+            "$ref": "#/responses/InternalError"
+        },
+        "default": {
+            "$ref": "#/responses/InternalError"
+        }
+    }
+}`
+	assert.Equal(t, expected, string(b))
+
+	assert.EqualError(t, operation.ParseComment(`@Failure 404 $ref:NotFound`, nil), "response NotFound is not defined, define it with @response.define")
+	assert.EqualError(t, operation.ParseComment(`@Failure abc $ref:InternalError`, nil), `can not parse response comment "abc $ref:InternalError"`)
+}
+
+func TestParseParamCommentWithRef(t *testing.T) {
+	t.Parallel()
+
+	parser := New()
+	parser.swagger.Parameters = map[string]spec.Parameter{"page": *spec.QueryParam("page")}
+
+	operation := NewOperation(parser)
+	assert.NoError(t, operation.ParseComment(`@Param $ref:page`, nil))
+	assert.Equal(t, []spec.Parameter{{Refable: spec.Refable{Ref: spec.MustCreateRef("#/parameters/page")}}}, operation.Parameters)
+
+	assert.EqualError(t, operation.ParseComment(`@Param $ref:size`, nil), "parameter size is not defined, define it with @param.define")
+}
+
 func TestParseResponseCommentWithObjectType(t *testing.T) {
 	t.Parallel()
 
@@ -674,6 +674,25 @@ func (pkgDefs *PackagesDefinitions) findTypeSpecFromPackagePaths(matchedPkgPaths
 	return typeDef
 }
 
+// TypeSpecs returns the type definitions of the parsed packages, sorted by type name.
+func (pkgDefs *PackagesDefinitions) TypeSpecs() []*TypeSpecDef {
+	typeSpecs := make([]*TypeSpecDef, 0, len(pkgDefs.uniqueDefinitions))
+	seen := make(map[*TypeSpecDef]bool, len(pkgDefs.uniqueDefinitions))
+
+	for _, typeSpec := range pkgDefs.uniqueDefinitions {
+		if typeSpec != nil && !seen[typeSpec] {
+			seen[typeSpec] = true
+			typeSpecs = append(typeSpecs, typeSpec)
+		}
+	}
+
+	sort.Slice(typeSpecs, func(i, j int) bool {
+		return typeSpecs[i].TypeName() < typeSpecs[j].TypeName()
+	})
+
+	return typeSpecs
+}
+
 // FindTypeSpec finds out TypeSpecDef of a type by typeName
 // @typeName the name of the target type, if it starts with a package name, find its own package path from imports on top of @file
 // @file the ast.file in which @typeName is used
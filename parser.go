@@ -68,6 +68,8 @@ const (
 	xCodeSamplesAttr        = "@x-codesamples"
 	scopeAttrPrefix         = "@scope."
 	stateAttr               = "@state"
+	paramDefineAttr         = "@param.define"
+	responseDefineAttr      = "@response.define"
 )
 
 // ParseFlag determine what to parse
@@ -183,6 +185,43 @@ type Parser struct {
 	// tags to filter the APIs after
 	tags map[string]struct{}
 
+	// operationPackages store the import path of the package declaring each operation
+	operationPackages map[*spec.Operation]string
+
+	// globalDefinitions are the parameters and responses defined in the general API info,
+	// which are resolved once the types are parsed
+	globalDefinitions []globalDefinition
+
+	// generalInfoFile is the ast.File the general API info is written in
+	generalInfoFile *ast.File
+
+	// generalInfoPath is the absolute path of the file the general API info is written in
+	generalInfoPath string
+
+	// templates are the operation templates declared with @Template, by name
+	templates map[string]*operationTemplate
+
+	// packageGroups are the annotations of the doc.go package comments, by package path
+	packageGroups map[string]*routerGroup
+
+	// fileGroups are the annotations of the package comments of the other files
+	fileGroups map[*ast.File]*routerGroup
+
+	// webhooks are the webhooks declared with @Webhook, by name
+	webhooks map[string]spec.PathItem
+
+	// callbackOperations are the operations having callbacks to resolve
+	callbackOperations []*Operation
+
+	// parsedOperations are the operations parsed from the comments of the files
+	parsedOperations []ParsedOperation
+
+	// diagnostics are the errors and warnings reported while parsing
+	diagnostics Diagnostics
+
+	// generalInfoPosition is the position of the general API info comment being parsed
+	generalInfoPosition token.Position
+
 	// HostState is the state of the host
 	HostState string
 
@@ -191,6 +230,16 @@ type Parser struct {
 
 	// UseStructName Dont use those ugly full-path names when using dependency flag
 	UseStructName bool
+
+	// GoDocDescriptions whether swag should use the Go doc comments of the operations as
+	// their summary and description when they have none
+	GoDocDescriptions bool
+
+	// definitionNameTemplate is the template naming the definitions, like {{.Type}}
+	definitionNameTemplate string
+
+	// definitionNamer names the definitions with definitionNameTemplate when it is set
+	definitionNamer *definitionNamer
 }
 
 // FieldParserFactory create FieldParser.
@@ -249,6 +298,11 @@ func New(options ...func(*Parser)) *Parser {
 		outputSchemas:      make(map[*TypeSpecDef]*Schema),
 		excludes:           make(map[string]struct{}),
 		tags:               make(map[string]struct{}),
+		operationPackages:  make(map[*spec.Operation]string),
+		templates:          make(map[string]*operationTemplate),
+		packageGroups:      make(map[string]*routerGroup),
+		fileGroups:         make(map[*ast.File]*routerGroup),
+		webhooks:           make(map[string]spec.PathItem),
 		fieldParserFactory: newTagBaseFieldParser,
 		Overrides:          make(map[string]string),
 	}
@@ -279,6 +333,23 @@ func SetUseStructName(useStructName bool) func(*Parser) {
 	}
 }
 
+// SetGoDocDescriptions sets whether to use the Go doc comments of the operations as their
+// default summary and description.
+func SetGoDocDescriptions(goDocDescriptions bool) func(*Parser) {
+	return func(p *Parser) {
+		p.GoDocDescriptions = goDocDescriptions
+	}
+}
+
+// SetDefinitionNameTemplate sets the text/template naming the definitions instead of
+// package.Type, executed with the Package, Type, TypeArgs and ImportPath of their type,
+// like {{.Type}}{{.TypeArgs}}.
+func SetDefinitionNameTemplate(text string) func(*Parser) {
+	return func(p *Parser) {
+		p.definitionNameTemplate = text
+	}
+}
+
 // SetMarkdownFileDirectory sets the directory to search for markdown files.
 func SetMarkdownFileDirectory(directoryPath string) func(*Parser) {
 	return func(p *Parser) {
@@ -410,6 +481,12 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 	if err != nil {
 		return err
 	}
+	if parser.definitionNameTemplate != "" {
+		parser.definitionNamer, err = newDefinitionNamer(parser.definitionNameTemplate)
+		if err != nil {
+			return err
+		}
+	}
 	if parser.ParseGoPackages {
 		if err := parser.loadPackagesAndDeps(searchDirs, absMainAPIFilePath); err != nil {
 			return err
@@ -491,7 +568,40 @@ func (parser *Parser) ParseAPIMultiSearchDir(searchDirs []string, mainAPIFile st
 		return err
 	}
 
-	err = parser.packages.RangeFiles(parser.ParseRouterAPIInfo)
+	if parser.definitionNamer != nil {
+		err = parser.definitionNamer.nameTypes(parser.packages.TypeSpecs())
+		if err != nil {
+			return err
+		}
+	}
+
+	err = parser.parseGlobalDefinitions()
+	if err != nil {
+		return err
+	}
+
+	err = parser.packages.RangeFiles(parser.collectTemplates)
+	if err != nil {
+		return err
+	}
+
+	err = parser.packages.RangeFiles(parser.collectRouterGroups)
+	if err != nil {
+		return err
+	}
+
+	err = parser.packages.RangeFiles(func(fileInfo *AstFileInfo) error {
+		return parser.diagnostics.collect(parser.ParseRouterAPIInfo(fileInfo))
+	})
+	if err != nil {
+		return err
+	}
+
+	if errs := parser.diagnostics.Errors(); len(errs) > 0 {
+		return errs
+	}
+
+	err = parser.resolveWebhooks()
 	if err != nil {
 		return err
 	}
@@ -527,12 +637,16 @@ func getPkgName(searchDir string) (string, error) {
 
 // ParseGeneralAPIInfo parses general api info for given mainAPIFile path.
 func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
-	fileTree, err := goparser.ParseFile(token.NewFileSet(), mainAPIFile, nil, goparser.ParseComments)
+	fileSet := token.NewFileSet()
+
+	fileTree, err := goparser.ParseFile(fileSet, mainAPIFile, nil, goparser.ParseComments)
 	if err != nil {
 		return fmt.Errorf("cannot parse source files %s: %s", mainAPIFile, err)
 	}
 
 	parser.swagger.Swagger = "2.0"
+	parser.generalInfoFile = fileTree
+	parser.generalInfoPath, _ = filepath.Abs(mainAPIFile)
 
 	for _, comment := range fileTree.Comments {
 		comments := strings.Split(comment.Text(), "\n")
@@ -540,9 +654,62 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 			continue
 		}
 
+		parser.generalInfoPosition = fileSet.Position(comment.Pos())
+
 		err = parseGeneralAPIInfo(parser, comments)
 		if err != nil {
-			return err
+			return newDiagnostic(err, parser.generalInfoPosition, InvalidCommentCode)
+		}
+	}
+
+	return nil
+}
+
+// globalDefinition is a parameter or response defined in the general API info.
+type globalDefinition struct {
+	attribute string
+	name      string
+	value     string
+}
+
+// parseGlobalDefinitions resolves the parameters and responses defined in the general
+// API info, in the same way as the @Param and @Response comments of operations.
+func (parser *Parser) parseGlobalDefinitions() error {
+	for _, definition := range parser.globalDefinitions {
+		operation := NewOperation(parser)
+
+		switch definition.attribute {
+		case paramDefineAttr:
+			err := operation.ParseParamComment(definition.value, parser.generalInfoFile)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", paramDefineAttr, definition.name, err)
+			}
+
+			if len(operation.Parameters) != 1 {
+				return fmt.Errorf("%s %s must define a single parameter", paramDefineAttr, definition.name)
+			}
+
+			if parser.swagger.Parameters == nil {
+				parser.swagger.Parameters = make(map[string]spec.Parameter)
+			}
+
+			parser.swagger.Parameters[definition.name] = operation.Parameters[0]
+		case responseDefineAttr:
+			err := operation.ParseResponseComment(defaultTag+" "+definition.value, parser.generalInfoFile)
+			if err != nil {
+				return fmt.Errorf("%s %s: %w", responseDefineAttr, definition.name, err)
+			}
+
+			response := operation.Responses.Default
+			if response.Description == "" {
+				return fmt.Errorf("%s %s needs a description", responseDefineAttr, definition.name)
+			}
+
+			if parser.swagger.Responses == nil {
+				parser.swagger.Responses = make(map[string]spec.Response)
+			}
+
+			parser.swagger.Responses[definition.name] = *response
 		}
 	}
 
@@ -552,8 +719,12 @@ func (parser *Parser) ParseGeneralAPIInfo(mainAPIFile string) error {
 func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 	previousAttribute := ""
 	var tag *spec.Tag
+	inTemplate := templateBlockLines(comments)
 	// parsing classic meta data model
 	for line := 0; line < len(comments); line++ {
+		if inTemplate[line] {
+			continue
+		}
 		commentLine := comments[line]
 		commentLine = strings.TrimSpace(commentLine)
 		if len(commentLine) == 0 {
@@ -595,7 +766,7 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 			if parser.HostState == fields[1] {
 				parser.swagger.Host = fields[2]
 			}
-		case "@basepath":
+		case basePathAttr:
 			parser.swagger.BasePath = value
 
 		case acceptAttr:
@@ -662,6 +833,18 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 		case "@query.collection.format":
 			parser.collectionFormatInQuery = TransToValidCollectionFormat(value)
 
+		case paramDefineAttr, responseDefineAttr:
+			fields = FieldsByAnySpace(value, 2)
+			if len(fields) != 2 {
+				return fmt.Errorf("%s needs a name and a definition", attribute)
+			}
+
+			parser.globalDefinitions = append(parser.globalDefinitions, globalDefinition{
+				attribute: attr,
+				name:      fields[0],
+				value:     fields[1],
+			})
+
 		case extDocsDescAttr, extDocsURLAttr:
 			if parser.swagger.ExternalDocs == nil {
 				parser.swagger.ExternalDocs = new(spec.ExternalDocumentation)
@@ -674,6 +857,14 @@ func parseGeneralAPIInfo(parser *Parser, comments []string) error {
 			}
 
 		default:
+			// The comments of the general API info file which are not operations are parsed
+			// as general API info too.
+			err := parser.checkAttribute(parser.generalInfoPosition, attribute,
+				append(generalAttributeNames, operationAttributeNames...), generalAttributeNames)
+			if err != nil {
+				return err
+			}
+
 			if strings.HasPrefix(attribute, "@x-") {
 				extensionName := attribute[1:]
 
@@ -922,9 +1113,10 @@ func (parser *Parser) ParseProduceComment(commentLine string) error {
 }
 
 func isGeneralAPIComment(comments []string) bool {
-	for _, commentLine := range comments {
+	inTemplate := templateBlockLines(comments)
+	for i, commentLine := range comments {
 		commentLine = strings.TrimSpace(commentLine)
-		if len(commentLine) == 0 {
+		if len(commentLine) == 0 || inTemplate[i] {
 			continue
 		}
 		attribute := strings.ToLower(FieldsByAnySpace(commentLine, 2)[0])
@@ -1010,51 +1202,98 @@ func getTagsFromComment(comment string) (tags []string) {
 }
 
 func (parser *Parser) matchTag(tag string) bool {
-	if len(parser.tags) == 0 {
+	return matchTagSet(parser.tags, tag)
+}
+
+// operationTags returns the tags of an operation without parsing its comment: the ones
+// of its @Tags lines and of the templates and groups it inherits.
+func (parser *Parser) operationTags(lines []string, fileInfo *AstFileInfo) []string {
+	var tags []string
+
+	used := make(map[string]bool)
+
+	var collect func(lines []string)
+	collect = func(lines []string) {
+		for _, line := range lines {
+			tags = append(tags, getTagsFromComment(line)...)
+
+			for _, name := range getUsesFromComment(line) {
+				template, ok := parser.templates[name]
+				if ok && !used[name] {
+					used[name] = true
+					collect(template.lines)
+				}
+			}
+		}
+	}
+
+	collect(lines)
+
+	for _, group := range []*routerGroup{parser.fileGroups[fileInfo.File], parser.packageGroups[fileInfo.PackagePath]} {
+		if group != nil {
+			collect(group.lines)
+		}
+	}
+
+	return tags
+}
+
+// getUsesFromComment returns the names of the templates of a @Use comment line.
+func getUsesFromComment(comment string) []string {
+	fields := FieldsByAnySpace(strings.TrimSpace(strings.TrimLeft(comment, "/")), 2)
+	if len(fields) != 2 || strings.ToLower(fields[0]) != useAttr {
+		return nil
+	}
+
+	return splitTemplateNames(fields[1])
+}
+
+// matchTagSet reports whether tag is selected by the set of tags, in which tags
+// prefixed with '!' are excluded.
+func matchTagSet(set map[string]struct{}, tag string) bool {
+	if len(set) == 0 {
 		return true
 	}
 
-	if _, has := parser.tags["!"+tag]; has {
+	if _, has := set["!"+tag]; has {
 		return false
 	}
-	if _, has := parser.tags[tag]; has {
+	if _, has := set[tag]; has {
 		return true
 	}
 
 	// If all tags are negation then we should return true
-	for key := range parser.tags {
-		if key[0] != '!' {
-			return false
-		}
-	}
-	return true
+	return onlyNegatedTags(set)
 }
 
-func (parser *Parser) matchTags(comments []*ast.Comment) (match bool) {
-	if len(parser.tags) == 0 {
+// matchTagSetAny reports whether an operation with the given tags is selected by the
+// set of tags: none of its tags is excluded and, unless the set only excludes tags, one
+// of them is included.
+func matchTagSetAny(set map[string]struct{}, tags []string) bool {
+	if len(set) == 0 {
 		return true
 	}
 
-	match = false
-	for _, comment := range comments {
-		for _, tag := range getTagsFromComment(comment.Text) {
-			if _, has := parser.tags["!"+tag]; has {
-				return false
-			}
-			if _, has := parser.tags[tag]; has {
-				match = true // keep iterating as it may contain a tag that is excluded
-			}
+	match := false
+	for _, tag := range tags {
+		if _, has := set["!"+tag]; has {
+			return false
+		}
+		if _, has := set[tag]; has {
+			match = true // keep iterating as it may contain a tag that is excluded
 		}
 	}
 
-	if !match {
-		// If all tags are negation then we should return true
-		for key := range parser.tags {
-			if key[0] != '!' {
-				return false
-			}
+	return match || onlyNegatedTags(set)
+}
+
+func onlyNegatedTags(set map[string]struct{}) bool {
+	for key := range set {
+		if key[0] != '!' {
+			return false
 		}
 	}
+
 	return true
 }
 
@@ -1113,47 +1352,107 @@ func (parser *Parser) ParseRouterAPIInfo(fileInfo *AstFileInfo) error {
 		return nil
 	}
 
+	// the operations of the file are all parsed, and their diagnostics returned together
+	var diagnostics Diagnostics
+
 	// parse File.Comments instead of File.Decls.Doc if ParseFuncBody flag set to "true"
 	if parser.ParseFuncBody {
 		for _, astComments := range fileInfo.File.Comments {
 			if astComments.List != nil {
-				if err := parser.parseRouterAPIInfoComment(astComments.List, fileInfo); err != nil {
+				if err := diagnostics.collect(parser.parseRouterAPIInfoComment(astComments.List, fileInfo)); err != nil {
 					return err
 				}
 			}
 		}
-
-		return nil
-	}
-
-	for _, decl := range fileInfo.File.Decls {
-		funcDoc, ok := getFuncDoc(decl)
-		if ok && funcDoc != nil && funcDoc.List != nil {
-			if err := parser.parseRouterAPIInfoComment(funcDoc.List, fileInfo); err != nil {
-				return err
+	} else {
+		for _, decl := range fileInfo.File.Decls {
+			funcDoc, ok := getFuncDoc(decl)
+			if ok && funcDoc != nil && funcDoc.List != nil {
+				if err := diagnostics.collect(parser.parseRouterAPIInfoComment(funcDoc.List, fileInfo)); err != nil {
+					return err
+				}
 			}
 		}
 	}
 
+	if len(diagnostics) > 0 {
+		return diagnostics
+	}
+
 	return nil
 }
 
 func (parser *Parser) parseRouterAPIInfoComment(comments []*ast.Comment, fileInfo *AstFileInfo) error {
-	if parser.matchTags(comments) && matchExtension(parser.parseExtension, comments) {
+	if matchExtension(parser.parseExtension, comments) {
 		// for per 'function' comment, create a new 'Operation' object
 		operation := NewOperation(parser, SetCodeExampleFilesDirectory(parser.codeExampleFilesDir))
-		for _, comment := range comments {
+		operation.packagePath = fileInfo.PackagePath
+
+		// the lines of blocks are parsed with their attribute
+		blockComments := joinCommentBlocks(comments)
+
+		lines := make([]string, 0, len(blockComments))
+		for _, comment := range blockComments {
+			lines = append(lines, comment.Text)
+		}
+
+		inTemplate := templateBlockLines(lines)
+
+		if len(parser.tags) > 0 {
+			ownLines := make([]string, 0, len(lines))
+			for i, line := range lines {
+				if !inTemplate[i] {
+					ownLines = append(ownLines, line)
+				}
+			}
+
+			// the tags inherited from templates and groups are filtered too
+			if !matchTagSetAny(parser.tags, parser.operationTags(ownLines, fileInfo)) {
+				return nil
+			}
+		}
+
+		position := parser.commentPosition(fileInfo, comments[0])
+
+		for i, comment := range blockComments {
+			if inTemplate[i] {
+				continue
+			}
+			operation.position = parser.commentPosition(fileInfo, comment)
 			err := operation.ParseComment(comment.Text, fileInfo.File)
 			if err != nil {
-				return fmt.Errorf("ParseComment error in file %s for comment: '%s': %+v", fileInfo.Path, comment.Text, err)
+				return newDiagnostic(err, operation.position, InvalidCommentCode)
 			}
 			if operation.State != "" && operation.State != parser.HostState {
 				return nil
 			}
 		}
-		err := processRouterOperation(parser, operation)
+		operation.position = position
+		err := operation.applyTemplates()
 		if err != nil {
-			return err
+			return newDiagnostic(err, position, InvalidCommentCode)
+		}
+		err = operation.applyRouterGroups(fileInfo)
+		if err != nil {
+			return newDiagnostic(err, position, InvalidCommentCode)
+		}
+		if parser.GoDocDescriptions {
+			operation.applyGoDoc(blockComments, inTemplate)
+		}
+		err = processRouterOperation(parser, operation)
+		if err != nil {
+			return newDiagnostic(err, position, InvalidCommentCode)
+		}
+		err = parser.processWebhookOperation(operation)
+		if err != nil {
+			return newDiagnostic(err, position, InvalidCommentCode)
+		}
+		if len(operation.RouterProperties) > 0 || len(operation.Webhooks) > 0 {
+			parser.parsedOperations = append(parser.parsedOperations, ParsedOperation{
+				Operation: operation,
+				Comments:  comments,
+				File:      fileInfo,
+			})
 		}
 	}
 
@@ -1197,12 +1496,11 @@ func processRouterOperation(parser *Parser, operation *Operation) error {
 
 		// check if we already have an operation for this path and method
 		if *op != nil {
-			err := fmt.Errorf("route %s %s is declared multiple times", routeProperties.HTTPMethod, routeProperties.Path)
-			if parser.Strict {
+			err := parser.warn(operation.position, DuplicateRouteCode,
+				fmt.Errorf("route %s %s is declared multiple times", routeProperties.HTTPMethod, routeProperties.Path))
+			if err != nil {
 				return err
 			}
-
-			parser.debug.Printf("warning: %s\n", err)
 		}
 
 		if len(operation.RouterProperties) > 1 {
@@ -1225,6 +1523,8 @@ func processRouterOperation(parser *Parser, operation *Operation) error {
 			(*op).Deprecated = routeProperties.Deprecated
 		}
 
+		parser.operationPackages[*op] = operation.packagePath
+
 		parser.swagger.Paths.Paths[routeProperties.Path] = pathItem
 	}
 
@@ -1360,7 +1660,10 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 		parser.debug.Printf("Skipping '%s', recursion detected.", typeName)
 
 		// Ensure SchemaName is set before using it
-		typeSpecDef.SetSchemaName()
+		err := parser.setSchemaName(typeSpecDef)
+		if err != nil {
+			return nil, err
+		}
 		schemaName := typeName
 		if typeSpecDef.SchemaName != "" {
 			schemaName = typeSpecDef.SchemaName
@@ -1374,7 +1677,13 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 			ErrRecursiveParseStruct
 	}
 
-	if parser.UseStructName {
+	if parser.definitionNamer != nil {
+		err := parser.setSchemaName(typeSpecDef)
+		if err != nil {
+			return nil, err
+		}
+		typeName = typeSpecDef.SchemaName
+	} else if parser.UseStructName {
 		schemaName := strings.Split(typeSpecDef.SchemaName, ".")
 		if len(schemaName) > 1 {
 			typeSpecDef.SchemaName = schemaName[len(schemaName)-1]
@@ -1445,6 +1754,25 @@ func (parser *Parser) ParseDefinition(typeSpecDef *TypeSpecDef) (*Schema, error)
 	return &sch, nil
 }
 
+// setSchemaName sets the name of the definition of a type, given by the definition name
+// template when it is set.
+func (parser *Parser) setSchemaName(typeSpecDef *TypeSpecDef) error {
+	if parser.definitionNamer == nil || (typeSpecDef.typeArgs == nil && ignoreNameOverride(typeSpecDef.Name())) {
+		typeSpecDef.SetSchemaName()
+
+		return nil
+	}
+
+	name, err := parser.definitionNamer.name(typeSpecDef)
+	if err != nil {
+		return err
+	}
+
+	typeSpecDef.SchemaName = name
+
+	return nil
+}
+
 func fullTypeName(parts ...string) string {
 	return strings.Join(parts, ".")
 }
@@ -2033,11 +2361,67 @@ func walkWith(excludes map[string]struct{}, parseVendor bool) func(path string,
 	}
 }
 
+// ParsedOperation is an operation parsed from the comments of a file.
+type ParsedOperation struct {
+	*Operation
+
+	// Comments are the comment lines the operation is parsed from
+	Comments []*ast.Comment
+
+	// File is the file declaring the operation
+	File *AstFileInfo
+}
+
+// ParsedOperations returns the routes and webhooks parsed from the comments of the
+// files, in the order they are parsed.
+func (parser *Parser) ParsedOperations() []ParsedOperation {
+	return parser.parsedOperations
+}
+
+// commentPosition returns the position of a comment of the file, relative to the working
+// directory when possible.
+func (parser *Parser) commentPosition(fileInfo *AstFileInfo, comment *ast.Comment) token.Position {
+	position := parser.Position(fileInfo.File, comment.Pos())
+	if position.Filename == "" {
+		position.Filename = fileInfo.Path
+	}
+
+	return position
+}
+
+// Packages returns the packages and files parsed by the parser.
+func (parser *Parser) Packages() *PackagesDefinitions {
+	return parser.packages
+}
+
+// Position returns the position of pos in file, a file parsed by the parser.
+func (parser *Parser) Position(file *ast.File, pos token.Pos) token.Position {
+	fileInfo, ok := parser.packages.files[file]
+	if !ok || fileInfo.FileSet == nil {
+		return token.Position{}
+	}
+
+	return fileInfo.FileSet.Position(pos)
+}
+
 // GetSwagger returns *spec.Swagger which is the root document object for the API specification.
 func (parser *Parser) GetSwagger() *spec.Swagger {
 	return parser.swagger
 }
 
+// GetDefinitionTypeSpecs returns the type definitions behind the schemas in swagger
+// definitions, keyed by definition name.
+func (parser *Parser) GetDefinitionTypeSpecs() map[string]*TypeSpecDef {
+	typeSpecs := make(map[string]*TypeSpecDef, len(parser.outputSchemas))
+	for typeSpecDef, schema := range parser.outputSchemas {
+		if _, ok := parser.swagger.Definitions[schema.Name]; ok {
+			typeSpecs[schema.Name] = typeSpecDef
+		}
+	}
+
+	return typeSpecs
+}
+
 // addTestType just for tests.
 func (parser *Parser) addTestType(typename string) {
 	typeDef := &TypeSpecDef{}
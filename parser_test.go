@@ -371,7 +371,7 @@ func TestParser_ParseGeneralApiInfoExtensions(t *testing.T) {
 	t.Run("Test invalid extension value", func(t *testing.T) {
 		t.Parallel()
 
-		expected := "annotation @x-google-endpoints need a valid json value"
+		expected := "testdata/extensionsFail1.go:14:1: annotation @x-google-endpoints need a valid json value"
 		gopath := os.Getenv("GOPATH")
 		assert.NotNil(t, gopath)
 
@@ -387,7 +387,7 @@ func TestParser_ParseGeneralApiInfoExtensions(t *testing.T) {
 	t.Run("Test missing extension value", func(t *testing.T) {
 		t.Parallel()
 
-		expected := "annotation @x-google-endpoints need a value"
+		expected := "testdata/extensionsFail2.go:14:1: annotation @x-google-endpoints need a value"
 		gopath := os.Getenv("GOPATH")
 		assert.NotNil(t, gopath)
 
@@ -2282,6 +2282,37 @@ func TestParseConflictSchemaName(t *testing.T) {
 	assert.Equal(t, string(expected), string(b))
 }
 
+func TestParseGlobalDefinitions(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/global_definitions"
+	p := New()
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
+
+func TestParser_parseGlobalDefinitions(t *testing.T) {
+	t.Parallel()
+
+	parse := func(comments ...string) error {
+		p := New()
+		if err := parseGeneralAPIInfo(p, comments); err != nil {
+			return err
+		}
+
+		return p.parseGlobalDefinitions()
+	}
+
+	assert.NoError(t, parse(`@param.define id id path int true "ID"`, `@response.define Gone "Gone"`))
+	assert.EqualError(t, parse(`@param.define id`), "@param.define needs a name and a definition")
+	assert.EqualError(t, parse(`@param.define id path int`), `@param.define id: missing required param comment parameters "path int"`)
+	assert.EqualError(t, parse(`@response.define Error {object} string`), "@response.define Error needs a description")
+}
+
 func TestParseExternalModels(t *testing.T) {
 	searchDir := "testdata/external_models/main"
 	mainAPIFile := "main.go"
@@ -3062,7 +3093,7 @@ func FunctionTwo(w http.ResponseWriter, r *http.Request) {
 	assert.NoError(t, err)
 
 	err = p.packages.RangeFiles(p.ParseRouterAPIInfo)
-	assert.EqualError(t, err, "route GET /api/endpoint is declared multiple times")
+	assert.EqualError(t, err, "api/api.go:13:1: route GET /api/endpoint is declared multiple times")
 
 	p = New()
 	err = p.packages.ParseFile("api", "api/api.go", src, ParseAll)
@@ -4306,45 +4337,50 @@ func Test_getTagsFromComment(t *testing.T) {
 	}
 }
 
-func TestParser_matchTags(t *testing.T) {
+func TestParser_ParseInheritedTags(t *testing.T) {
+	t.Parallel()
 
-	type args struct {
-		comments []*ast.Comment
-	}
-	tests := []struct {
-		name      string
-		parser    *Parser
-		args      args
-		wantMatch bool
-	}{
-		{
-			name:      "no tags filter",
-			parser:    New(),
-			args:      args{comments: []*ast.Comment{{Text: "//@Tags tag1,tag2,tag3"}}},
-			wantMatch: true,
-		},
-		{
-			name:      "with tags filter but no match",
-			parser:    New(SetTags("tag4,tag5,!tag1")),
-			args:      args{comments: []*ast.Comment{{Text: "//@Tags tag1,tag2,tag3"}}},
-			wantMatch: false,
-		},
-		{
-			name:      "with tags filter but match",
-			parser:    New(SetTags("tag4,tag5,tag1")),
-			args:      args{comments: []*ast.Comment{{Text: "//@Tags tag1,tag2,tag3"}}},
-			wantMatch: true,
-		},
+	tests := map[string][]string{
+		"audit":  {"/orders"},
+		"!audit": {"/orders/{id}", "/admin/users", "/admin/users/{id}", "/admin/audit"},
+		"orders": {"/orders/{id}"},
+		"admin":  {"/admin/users", "/admin/users/{id}", "/admin/audit"},
+		"!admin": {"/orders", "/orders/{id}"},
+		"users":  {"/admin/users", "/admin/users/{id}"},
+		"!users": {"/orders", "/orders/{id}", "/admin/audit"},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if gotMatch := tt.parser.matchTags(tt.args.comments); gotMatch != tt.wantMatch {
-				t.Errorf("Parser.matchTags() = %v, want %v", gotMatch, tt.wantMatch)
-			}
-		})
+
+	for tags, paths := range tests {
+		p := New(SetTags(tags))
+		err := p.ParseAPI("testdata/inherited_tags", mainAPIFile, defaultParseDepth)
+		assert.NoError(t, err)
+
+		var got []string
+		for path := range p.swagger.Paths.Paths {
+			got = append(got, path)
+		}
+
+		assert.ElementsMatch(t, paths, got, tags)
 	}
 }
 
+func TestParser_ParseExcludedTags(t *testing.T) {
+	t.Parallel()
+
+	p := New(SetTags("public"))
+	err := p.ParseAPI("testdata/excluded_tags", mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+
+	assert.Contains(t, p.swagger.Paths.Paths, "/public")
+	assert.NotContains(t, p.swagger.Paths.Paths, "/internal")
+	assert.Contains(t, p.swagger.Definitions, "api.Public")
+	assert.NotContains(t, p.swagger.Definitions, "api.Secret")
+
+	p = New(SetTags("internal"))
+	err = p.ParseAPI("testdata/excluded_tags", mainAPIFile, defaultParseDepth)
+	assert.ErrorContains(t, err, "badtype")
+}
+
 func TestParser_parseExtension(t *testing.T) {
 	packagePath := "testdata/parseExtension"
 	filePath := packagePath + "/parseExtension.go"
@@ -0,0 +1,281 @@
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	linkAttr = "@link"
+
+	// xContentExtension holds the bodies of a response by media type, like the content
+	// of an OpenAPI 3 response.
+	xContentExtension = "x-content"
+
+	// xLinksExtension holds the links of a response by name, like the links of an
+	// OpenAPI 3 response.
+	xLinksExtension = "x-links"
+
+	mimeOption    = "mime"
+	exampleOption = "example"
+
+	exampleFilePrefix = "file:"
+)
+
+// responseMediaType is the body of a response for a media type.
+type responseMediaType struct {
+	Schema *spec.Schema `json:"schema,omitempty"`
+}
+
+// responseLink describes an operation which can be called with values of a response.
+type responseLink struct {
+	OperationID string            `json:"operationId"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+var responseOptionPattern = regexp.MustCompile(`\s+(` + mimeOption + `|` + exampleOption + `)\(([^)]*)\)\s*$`)
+
+// splitResponseOptions removes the trailing options of a response comment, like
+// mime(text/csv) or example(file:user.json), and returns them by name.
+func splitResponseOptions(commentLine string) (string, map[string]string, error) {
+	options := make(map[string]string)
+
+	for {
+		matches := responseOptionPattern.FindStringSubmatch(commentLine)
+		if matches == nil {
+			return commentLine, options, nil
+		}
+
+		if _, ok := options[matches[1]]; ok {
+			return "", nil, fmt.Errorf("%s option is given twice in response comment \"%s\"", matches[1], commentLine)
+		}
+
+		options[matches[1]] = strings.TrimSpace(matches[2])
+		commentLine = strings.TrimSuffix(commentLine, matches[0])
+	}
+}
+
+// parseResponseWithOptions parses a response comment having options. A response given
+// with mime() is the body of the status code for that media type, and is added to the
+// bodies of a response already declared for the code, the body of a response declared
+// without mime() being the one of application/json. A response given with example() has
+// that example for its media type, application/json by default.
+func (operation *Operation) parseResponseWithOptions(commentLine string, options map[string]string, astFile *ast.File) error {
+	codes := strings.Split(FieldsByAnySpace(commentLine, 2)[0], ",")
+
+	previous := make(map[string]spec.Response, len(codes))
+
+	for _, code := range codes {
+		if response, ok := operation.loadResponse(code); ok {
+			previous[code] = response
+		}
+	}
+
+	err := operation.ParseResponseComment(commentLine, astFile)
+	if err != nil {
+		return err
+	}
+
+	mimeType := mimeTypeAliases["json"]
+
+	value, hasMime := options[mimeOption]
+	if hasMime {
+		var mimeTypes []string
+
+		err = parseMimeTypeList(value, &mimeTypes, "%v media type can't be accepted")
+		if err != nil {
+			return err
+		}
+
+		if len(mimeTypes) != 1 {
+			return fmt.Errorf("%s option needs a single media type", mimeOption)
+		}
+
+		mimeType = mimeTypes[0]
+
+		if !findInSlice(operation.Produces, mimeType) {
+			operation.Produces = append(operation.Produces, mimeType)
+		}
+	}
+
+	var example any
+
+	if value, ok := options[exampleOption]; ok {
+		example, err = operation.parseResponseExample(value)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, code := range codes {
+		response, _ := operation.loadResponse(code)
+
+		if hasMime {
+			mediaType := responseMediaType{Schema: response.Schema}
+
+			content := make(map[string]responseMediaType)
+
+			if previousResponse, ok := previous[code]; ok {
+				// the body of a response declared without mime() is the one of the
+				// default media type
+				if !decodeExtension(previousResponse.Extensions, xContentExtension, &content) && previousResponse.Schema != nil {
+					content[mimeTypeAliases["json"]] = responseMediaType{Schema: previousResponse.Schema}
+				}
+
+				response = previousResponse
+			}
+
+			content[mimeType] = mediaType
+			response.AddExtension(xContentExtension, content)
+		}
+
+		if example != nil {
+			if response.Examples == nil {
+				response.Examples = make(map[string]any)
+			}
+
+			response.Examples[mimeType] = example
+		}
+
+		operation.storeResponse(code, response)
+	}
+
+	return nil
+}
+
+// parseResponseExample returns the example of a response, read from a file of the code
+// example files directory if prefixed with file:, and decoded when it holds JSON.
+func (operation *Operation) parseResponseExample(value string) (any, error) {
+	data := []byte(value)
+
+	if strings.HasPrefix(value, exampleFilePrefix) {
+		fileName := filepath.Join(operation.codeExampleFilesDir, strings.TrimPrefix(value, exampleFilePrefix))
+
+		var err error
+
+		data, err = os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response example file %s: %w", fileName, err)
+		}
+	}
+
+	var example any
+
+	err := json.Unmarshal(data, &example)
+	if err != nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	return example, nil
+}
+
+var linkDescriptionPattern = regexp.MustCompile(`\s+"(.*)"$`)
+
+// ParseLinkComment parses a link of responses already declared, to the operation which
+// can be called with their values. E.g.
+// @Link 201 GetUser getUserByID id=$response.body#/id "The created user".
+func (operation *Operation) ParseLinkComment(commentLine string) error {
+	var description string
+
+	if matches := linkDescriptionPattern.FindStringSubmatch(commentLine); matches != nil {
+		description = matches[1]
+		commentLine = strings.TrimSuffix(commentLine, matches[0])
+	}
+
+	fields := strings.Fields(commentLine)
+	if len(fields) < 3 {
+		return fmt.Errorf("can not parse link comment \"%s\"", commentLine)
+	}
+
+	link := responseLink{OperationID: fields[2], Description: description}
+
+	for _, param := range fields[3:] {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok || name == "" || value == "" {
+			return fmt.Errorf("link parameter %s needs a name=value form", param)
+		}
+
+		if link.Parameters == nil {
+			link.Parameters = make(map[string]string)
+		}
+
+		link.Parameters[name] = value
+	}
+
+	for _, code := range strings.Split(fields[0], ",") {
+		response, ok := operation.loadResponse(code)
+		if !ok {
+			return fmt.Errorf("response %s must be declared before its links", code)
+		}
+
+		links, ok := response.Extensions[xLinksExtension].(map[string]responseLink)
+		if !ok {
+			links = make(map[string]responseLink)
+			response.AddExtension(xLinksExtension, links)
+		}
+
+		links[fields[1]] = link
+
+		operation.storeResponse(code, response)
+	}
+
+	return nil
+}
+
+// loadResponse returns the response of the operation for the status code or default.
+func (operation *Operation) loadResponse(code string) (spec.Response, bool) {
+	if strings.EqualFold(code, defaultTag) {
+		if operation.Responses.Default == nil {
+			return spec.Response{}, false
+		}
+
+		return *operation.Responses.Default, true
+	}
+
+	statusCode, err := strconv.Atoi(code)
+	if err != nil {
+		return spec.Response{}, false
+	}
+
+	response, ok := operation.Responses.StatusCodeResponses[statusCode]
+
+	return response, ok
+}
+
+// storeResponse sets the response of the operation for the status code or default.
+func (operation *Operation) storeResponse(code string, response spec.Response) {
+	if strings.EqualFold(code, defaultTag) {
+		operation.Responses.Default = &response
+
+		return
+	}
+
+	statusCode, err := strconv.Atoi(code)
+	if err == nil {
+		operation.Responses.StatusCodeResponses[statusCode] = response
+	}
+}
+
+// responseContentSchemas returns the schemas of the bodies of the response by media type.
+func responseContentSchemas(response *spec.Response) []*spec.Schema {
+	var content map[string]responseMediaType
+	if !decodeExtension(response.Extensions, xContentExtension, &content) {
+		return nil
+	}
+
+	schemas := make([]*spec.Schema, 0, len(content))
+	for _, mediaType := range content {
+		schemas = append(schemas, mediaType.Schema)
+	}
+
+	return schemas
+}
@@ -0,0 +1,173 @@
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitResponseOptions(t *testing.T) {
+	t.Parallel()
+
+	line, options, err := splitResponseOptions(`200 {object} User "OK (cached)" mime(json) example(file:user.json)`)
+	require.NoError(t, err)
+	assert.Equal(t, `200 {object} User "OK (cached)"`, line)
+	assert.Equal(t, map[string]string{"mime": "json", "example": "file:user.json"}, options)
+
+	line, options, err = splitResponseOptions(`200 {object} User "Use mime(json)"`)
+	require.NoError(t, err)
+	assert.Equal(t, `200 {object} User "Use mime(json)"`, line)
+	assert.Empty(t, options)
+
+	_, _, err = splitResponseOptions(`200 {object} User mime(json) mime(csv)`)
+	assert.EqualError(t, err, `mime option is given twice in response comment "200 {object} User mime(json)"`)
+}
+
+func TestParseResponseCommentWithMime(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil, SetCodeExampleFilesDirectory("testdata/response_examples"))
+	for _, comment := range []string{
+		`@Produce json`,
+		`@Success 200 {array} string "Users" mime(json) example(file:user.json)`,
+		`@Success 200 {string} string "CSV export" mime(text/csv) example(file:users.csv)`,
+		`@Header 200 {string} X-Total-Count "Total"`,
+	} {
+		require.NoError(t, operation.ParseComment(comment, nil))
+	}
+
+	b, err := json.MarshalIndent(operation, "", "    ")
+	require.NoError(t, err)
+
+	expected := `{
+    "produces": [
+        "application/json",
+        "text/csv"
+    ],
+    "responses": {
+        "200": {
+            "description": "Users",
+            "schema": {
+                "type": "array",
+                "items": {
+                    "type": "string"
+                }
+            },
+            "headers": {
+                "X-Total-Count": {
+                    "type": "string",
+                    "description": "Total"
+                }
+            },
+            "examples": {
+                "application/json": {
+                    "id": 1,
+                    "name": "Ada"
+                },
+                "text/csv": "id,name\n1,Ada"
+            },
+            "x-content": {
+                "application/json": {
+                    "schema": {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "text/csv": {
+                    "schema": {
+                        "type": "string"
+                    }
+                }
+            }
+        }
+    }
+}`
+	assert.Equal(t, expected, string(b))
+
+	assert.EqualError(t, NewOperation(nil).ParseComment(`@Success 200 {string} string "OK" mime(text)`, nil), "text media type can't be accepted")
+	assert.EqualError(t, NewOperation(nil).ParseComment(`@Success 200 {string} string "OK" mime(json,xml)`, nil), "mime option needs a single media type")
+	assert.Error(t, NewOperation(nil).ParseComment(`@Success 200 {string} string "OK" example(file:missing.json)`, nil))
+}
+
+func TestParseResponseCommentWithMimeAfterDefault(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	require.NoError(t, operation.ParseComment(`@Success 200 {object} string "Users"`, nil))
+	require.NoError(t, operation.ParseComment(`@Success 200 {string} string "CSV export" mime(text/csv)`, nil))
+	require.NoError(t, operation.ParseComment(`@Success 200 {integer} int "Count" mime(text/plain)`, nil))
+
+	response := operation.Responses.StatusCodeResponses[200]
+	assert.Equal(t, "Users", response.Description)
+	assert.Equal(t, spec.StringProperty(), response.Schema)
+	assert.Equal(t, map[string]responseMediaType{
+		"application/json": {Schema: spec.StringProperty()},
+		"text/csv":         {Schema: spec.StringProperty()},
+		"text/plain":       {Schema: &spec.Schema{SchemaProps: spec.SchemaProps{Type: spec.StringOrArray{INTEGER}}}},
+	}, response.Extensions[xContentExtension])
+}
+
+func TestParseResponseCommentWithExample(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	require.NoError(t, operation.ParseComment(`@Success 200,default {object} string example({"id":1})`, nil))
+	require.NoError(t, operation.ParseComment(`@Failure 404 "Not found" example(no such user)`, nil))
+
+	assert.Equal(t, map[string]any{"application/json": map[string]any{"id": float64(1)}}, operation.Responses.StatusCodeResponses[200].Examples)
+	assert.Equal(t, map[string]any{"application/json": map[string]any{"id": float64(1)}}, operation.Responses.Default.Examples)
+	assert.Equal(t, "Not found", operation.Responses.StatusCodeResponses[404].Description)
+	assert.Equal(t, map[string]any{"application/json": "no such user"}, operation.Responses.StatusCodeResponses[404].Examples)
+}
+
+func TestParseLinkComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	require.NoError(t, operation.ParseComment(`@Success 201 {string} string "Created"`, nil))
+	require.NoError(t, operation.ParseComment(`@Link 201 GetUser getUserByID id=$response.body#/id "The created user"`, nil))
+	require.NoError(t, operation.ParseComment(`@Link 201 ListUsers listUsers`, nil))
+
+	b, err := json.Marshal(operation.Responses.StatusCodeResponses[201].Extensions)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"x-links": {
+		"GetUser": {"operationId": "getUserByID", "parameters": {"id": "$response.body#/id"}, "description": "The created user"},
+		"ListUsers": {"operationId": "listUsers"}
+	}}`, string(b))
+
+	assert.EqualError(t, operation.ParseComment(`@Link 200 GetUser getUserByID`, nil), "response 200 must be declared before its links")
+	assert.EqualError(t, operation.ParseComment(`@Link 201 GetUser`, nil), `can not parse link comment "201 GetUser"`)
+	assert.EqualError(t, operation.ParseComment(`@Link 201 GetUser getUserByID id`, nil), "link parameter id needs a name=value form")
+}
+
+func TestPruneDefinitionsKeepsResponseContent(t *testing.T) {
+	t.Parallel()
+
+	response := spec.NewResponse()
+	response.AddExtension(xContentExtension, map[string]responseMediaType{
+		"text/csv": {Schema: spec.RefSchema("#/definitions/Report")},
+	})
+
+	swagger := &spec.Swagger{SwaggerProps: spec.SwaggerProps{
+		Paths: &spec.Paths{Paths: map[string]spec.PathItem{
+			"/report": {PathItemProps: spec.PathItemProps{Get: spec.NewOperation("").RespondsWith(200, response)}},
+		}},
+		Definitions: spec.Definitions{"Report": *spec.StringProperty(), "Unused": *spec.StringProperty()},
+	}}
+
+	assert.Equal(t, []string{"Unused"}, PruneDefinitions(swagger))
+
+	// the bodies are read from a document decoded from JSON too
+	b, err := json.Marshal(swagger)
+	require.NoError(t, err)
+
+	var decoded spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Empty(t, PruneDefinitions(&decoded))
+	assert.Contains(t, decoded.Definitions, "Report")
+}
@@ -3,8 +3,13 @@ package swag
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"log"
 	"strings"
+	"sync"
 	"text/template"
+
+	"github.com/go-openapi/spec"
 )
 
 // Spec holds exported Swagger Info so clients can modify it.
@@ -19,10 +24,67 @@ type Spec struct {
 	SwaggerTemplate  string
 	LeftDelim        string
 	RightDelim       string
+
+	mu        sync.Mutex
+	modifiers []func(*spec.Swagger) error
+	// rendered is SwaggerTemplate rendered from the fields in key, or the error of the
+	// rendering, and document is the rendered document parsed and modified.
+	rendered  string
+	renderErr error
+	document  *spec.Swagger
+	key       string
+	// logged is the last failure logged by ReadDoc, so that it is logged once.
+	logged string
 }
 
-// ReadDoc parses SwaggerTemplate into swagger document.
+// ReadDoc parses SwaggerTemplate into swagger document. If the document has been
+// modified with Modify, the modified document is returned. The document is rendered
+// again only when a field of the spec changes. When the document cannot be modified or
+// rendered, the error is logged once and the unmodified document or SwaggerTemplate is
+// returned instead; Document and Render return the error.
 func (i *Spec) ReadDoc() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	doc, err := i.render()
+	if err != nil {
+		i.logOnce("swag: cannot render the document of %s, serving its template: %v", i.InfoInstanceName, err)
+
+		return i.SwaggerTemplate
+	}
+
+	if len(i.modifiers) == 0 {
+		return doc
+	}
+
+	err = i.refresh()
+	if err == nil {
+		var modified []byte
+
+		modified, err = json.MarshalIndent(i.document, "", "    ")
+		if err == nil {
+			return string(modified)
+		}
+	}
+
+	i.logOnce("swag: cannot modify the document of %s, serving it unmodified: %v", i.InfoInstanceName, err)
+
+	return doc
+}
+
+// logOnce logs a failure of ReadDoc unless it is the last one logged, so that a broken
+// document is not logged on every request.
+func (i *Spec) logOnce(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if message != i.logged {
+		i.logged = message
+		log.Print(message)
+	}
+}
+
+// Render executes SwaggerTemplate with the fields of the spec. Modifications made with
+// Modify are not applied.
+func (i *Spec) Render() (string, error) {
 	i.Description = strings.ReplaceAll(i.Description, "\n", "\\n")
 
 	tpl := template.New("swagger_info").Funcs(template.FuncMap{
@@ -47,18 +109,180 @@ func (i *Spec) ReadDoc() string {
 
 	parsed, err := tpl.Parse(i.SwaggerTemplate)
 	if err != nil {
-		return i.SwaggerTemplate
+		return "", fmt.Errorf("cannot parse swagger template: %w", err)
 	}
 
 	var doc bytes.Buffer
 	if err = parsed.Execute(&doc, i); err != nil {
-		return i.SwaggerTemplate
+		return "", fmt.Errorf("cannot execute swagger template: %w", err)
+	}
+
+	return doc.String(), nil
+}
+
+// Document returns the parsed document with the modifications made with Modify applied.
+// The document is cached until a field of the spec or its modifications change, so it
+// must not be changed by callers; use Modify instead.
+func (i *Spec) Document() (*spec.Swagger, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if err := i.refresh(); err != nil {
+		return nil, err
+	}
+
+	return i.document, nil
+}
+
+// MarshalDoc returns the document with the modifications made with Modify applied as JSON.
+func (i *Spec) MarshalDoc() ([]byte, error) {
+	swagger, err := i.Document()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(swagger, "", "    ")
+}
+
+// Modify changes the document with modifier. Modifiers are kept and applied in order
+// every time the document is rendered again, e.g. after Host has been changed. An error
+// returned by modifier is returned, and the modifier is discarded.
+func (i *Spec) Modify(modifier func(*spec.Swagger) error) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.modifiers = append(i.modifiers, modifier)
+	i.document = nil
+
+	if err := i.refresh(); err != nil {
+		i.modifiers = i.modifiers[:len(i.modifiers)-1]
+		i.document = nil
+
+		return err
 	}
 
-	return doc.String()
+	return nil
+}
+
+// render renders SwaggerTemplate again if the fields it is rendered from changed.
+func (i *Spec) render() (string, error) {
+	key := strings.Join([]string{
+		i.Version, i.Host, i.BasePath, strings.Join(i.Schemes, ","), i.Title,
+		strings.ReplaceAll(i.Description, "\n", "\\n"), i.SwaggerTemplate, i.LeftDelim, i.RightDelim,
+	}, "\x00")
+
+	if i.key != key {
+		i.rendered, i.renderErr = i.Render()
+		i.document, i.key = nil, key
+	}
+
+	return i.rendered, i.renderErr
+}
+
+// refresh parses the rendered document again if the fields it is rendered from changed.
+func (i *Spec) refresh() error {
+	doc, err := i.render()
+	if err != nil {
+		return err
+	}
+
+	if i.document != nil {
+		return nil
+	}
+
+	var swagger spec.Swagger
+	if err = json.Unmarshal([]byte(doc), &swagger); err != nil {
+		return fmt.Errorf("cannot parse swagger document: %w", err)
+	}
+
+	for _, modifier := range i.modifiers {
+		if err = modifier(&swagger); err != nil {
+			return err
+		}
+	}
+
+	i.document = &swagger
+
+	return nil
 }
 
 // InstanceName returns Spec instance name.
 func (i *Spec) InstanceName() string {
 	return i.InfoInstanceName
 }
+
+// RemoveOperationsByTag returns a modifier removing the operations tagged with any of tags,
+// and the tags themselves, e.g. to hide internal operations in production.
+func RemoveOperationsByTag(tags ...string) func(*spec.Swagger) error {
+	return func(swagger *spec.Swagger) error {
+		hidden := make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			hidden[tag] = true
+		}
+
+		isHidden := func(operation *spec.Operation) bool {
+			if operation == nil {
+				return false
+			}
+
+			for _, tag := range operation.Tags {
+				if hidden[tag] {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if swagger.Paths != nil {
+			for path, item := range swagger.Paths.Paths {
+				for _, operation := range []**spec.Operation{
+					&item.Get, &item.Put, &item.Post, &item.Delete, &item.Options, &item.Head, &item.Patch,
+				} {
+					if isHidden(*operation) {
+						*operation = nil
+					}
+				}
+
+				if item.Get == nil && item.Put == nil && item.Post == nil && item.Delete == nil &&
+					item.Options == nil && item.Head == nil && item.Patch == nil {
+					delete(swagger.Paths.Paths, path)
+
+					continue
+				}
+
+				swagger.Paths.Paths[path] = item
+			}
+		}
+
+		kept := swagger.Tags[:0]
+
+		for _, tag := range swagger.Tags {
+			if !hidden[tag.Name] {
+				kept = append(kept, tag)
+			}
+		}
+
+		swagger.Tags = kept
+
+		return nil
+	}
+}
+
+// AddSecurityDefinition returns a modifier adding the security scheme under name, e.g. to
+// document an authentication method only enabled in some environments.
+func AddSecurityDefinition(name string, scheme *spec.SecurityScheme) func(*spec.Swagger) error {
+	return func(swagger *spec.Swagger) error {
+		if _, ok := swagger.SecurityDefinitions[name]; ok {
+			return fmt.Errorf("security definition %q already exists", name)
+		}
+
+		if swagger.SecurityDefinitions == nil {
+			swagger.SecurityDefinitions = make(spec.SecurityDefinitions)
+		}
+
+		swagger.SecurityDefinitions[name] = scheme
+
+		return nil
+	}
+}
@@ -1,9 +1,17 @@
 package swag
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"strings"
 	"testing"
 
+	"github.com/go-openapi/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSpec_InstanceName(t *testing.T) {
@@ -186,3 +194,141 @@ func TestSpec_ReadDoc(t *testing.T) {
 		})
 	}
 }
+
+const modifiableDoc = `{
+    "swagger": "2.0",
+    "info": {
+        "title": "{{.Title}}",
+        "version": "1.0"
+    },
+    "host": "{{.Host}}",
+    "tags": [{"name": "pets"}, {"name": "internal"}],
+    "paths": {
+        "/pets": {
+            "get": {"tags": ["pets"], "responses": {"200": {"description": "OK"}}},
+            "delete": {"tags": ["internal"], "responses": {"200": {"description": "OK"}}}
+        },
+        "/debug": {
+            "get": {"tags": ["internal"], "responses": {"200": {"description": "OK"}}}
+        }
+    }
+}`
+
+func TestSpec_Render(t *testing.T) {
+	doc := &Spec{Host: "localhost", SwaggerTemplate: `{"host": "{{.Host}}"}`}
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.Equal(t, `{"host": "localhost"}`, rendered)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+
+	doc.SwaggerTemplate = `{"host": "{{.Host}"}`
+	_, err = doc.Render()
+	assert.Error(t, err)
+	assert.Equal(t, doc.SwaggerTemplate, doc.ReadDoc())
+	assert.Equal(t, doc.SwaggerTemplate, doc.ReadDoc())
+	// the failure is logged once
+	assert.Equal(t, 1, strings.Count(logs.String(), "cannot parse swagger template"))
+
+	doc.SwaggerTemplate = `{"host": "{{.Missing}}"}`
+	_, err = doc.Render()
+	assert.Error(t, err)
+}
+
+func TestSpec_ReadDocCached(t *testing.T) {
+	doc := &Spec{Host: "localhost", SwaggerTemplate: `{"host": "{{.Host}}"}`}
+
+	assert.Equal(t, `{"host": "localhost"}`, doc.ReadDoc())
+
+	// the template is not executed again until a field changes
+	doc.rendered = `{"host": "cached"}`
+	assert.Equal(t, `{"host": "cached"}`, doc.ReadDoc())
+
+	doc.Host = "example.com"
+	assert.Equal(t, `{"host": "example.com"}`, doc.ReadDoc())
+}
+
+func TestSpec_Document(t *testing.T) {
+	doc := &Spec{Title: "Pets", Host: "localhost", SwaggerTemplate: modifiableDoc}
+
+	swagger, err := doc.Document()
+	require.NoError(t, err)
+	assert.Equal(t, "Pets", swagger.Info.Title)
+	assert.Equal(t, "localhost", swagger.Host)
+
+	cached, err := doc.Document()
+	require.NoError(t, err)
+	assert.Same(t, swagger, cached)
+
+	doc.Host = "example.com"
+
+	swagger, err = doc.Document()
+	require.NoError(t, err)
+	assert.NotSame(t, cached, swagger)
+	assert.Equal(t, "example.com", swagger.Host)
+
+	doc.SwaggerTemplate = `{"host": "{{.Host}}",}`
+	_, err = doc.Document()
+	assert.Error(t, err)
+}
+
+func TestSpec_Modify(t *testing.T) {
+	doc := &Spec{Title: "Pets", Host: "localhost", SwaggerTemplate: modifiableDoc}
+
+	require.NoError(t, doc.Modify(RemoveOperationsByTag("internal")))
+	require.NoError(t, doc.Modify(AddSecurityDefinition("ApiKey", spec.APIKeyAuth("X-API-KEY", "header"))))
+	require.NoError(t, doc.Modify(func(swagger *spec.Swagger) error {
+		swagger.Schemes = []string{"https"}
+
+		return nil
+	}))
+
+	err := doc.Modify(AddSecurityDefinition("ApiKey", spec.BasicAuth()))
+	assert.EqualError(t, err, `security definition "ApiKey" already exists`)
+
+	// modifiers are applied again when the document is rendered from changed fields
+	doc.Host = "example.com"
+
+	swagger, err := doc.Document()
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", swagger.Host)
+	assert.Equal(t, []string{"https"}, swagger.Schemes)
+	assert.Equal(t, []spec.Tag{spec.NewTag("pets", "", nil)}, swagger.Tags)
+	assert.Len(t, swagger.Paths.Paths, 1)
+	assert.NotNil(t, swagger.Paths.Paths["/pets"].Get)
+	assert.Nil(t, swagger.Paths.Paths["/pets"].Delete)
+	assert.Equal(t, "apiKey", swagger.SecurityDefinitions["ApiKey"].Type)
+
+	var read spec.Swagger
+	require.NoError(t, json.Unmarshal([]byte(doc.ReadDoc()), &read))
+	assert.Equal(t, "example.com", read.Host)
+	assert.NotContains(t, read.Paths.Paths, "/debug")
+
+	// a modifier failing once the fields change is reported
+	require.NoError(t, doc.Modify(func(swagger *spec.Swagger) error {
+		if swagger.Host == "fail" {
+			return errors.New("cannot modify fail")
+		}
+
+		return nil
+	}))
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+	})
+
+	doc.Host = "fail"
+
+	_, err = doc.Document()
+	assert.EqualError(t, err, "cannot modify fail")
+	require.NoError(t, json.Unmarshal([]byte(doc.ReadDoc()), &read))
+	assert.Equal(t, "fail", read.Host)
+	assert.Contains(t, logs.String(), "cannot modify fail")
+}
@@ -0,0 +1,498 @@
+package stats
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// methodFuncs are the functions registering a route of a method, like r.GET(path, handler)
+// of gin and echo or r.Get(path, handler) of chi and fiber. Any registers every method.
+var methodFuncs = map[string]string{
+	"GET": http.MethodGet, "POST": http.MethodPost, "PUT": http.MethodPut, "PATCH": http.MethodPatch,
+	"DELETE": http.MethodDelete, "HEAD": http.MethodHead, "OPTIONS": http.MethodOptions, "Any": "",
+	"Get": http.MethodGet, "Post": http.MethodPost, "Put": http.MethodPut, "Patch": http.MethodPatch,
+	"Delete": http.MethodDelete, "Head": http.MethodHead, "Options": http.MethodOptions,
+}
+
+// handleFuncs are the functions registering a route of a pattern, like mux.HandleFunc(pattern,
+// handler) of net/http, or of a method and a path, like r.Handle(method, path, handler) of
+// gin or e.Add(method, path, handler) of echo.
+var handleFuncs = map[string]bool{
+	"Handle": true, "HandleFunc": true, "Add": true, "Method": true, "MethodFunc": true,
+}
+
+// groupFuncs are the functions returning a router of a path prefix, like r.Group(prefix) of
+// gin, echo and fiber, r.Route(prefix, fn) of chi or r.PathPrefix(prefix) of gorilla/mux.
+var groupFuncs = map[string]bool{
+	"Group": true, "Route": true, "PathPrefix": true,
+}
+
+// handler is a function registered as the handler of routes or documented by operations.
+type handler struct {
+	// pkgPath is the path of the package of the function, empty when unknown, like for
+	// method values
+	pkgPath string
+
+	// name is the name of the function, Type.Method for methods
+	name string
+}
+
+// matches reports whether a registered handler is the documented one.
+func (h handler) matches(documented handler) bool {
+	if h.name == "" {
+		return false
+	}
+
+	if h.pkgPath == "" {
+		return documented.name == h.name || strings.HasSuffix(documented.name, "."+h.name)
+	}
+
+	return h.pkgPath == documented.pkgPath && h.name == documented.name
+}
+
+// registration is a route found in a call registering it.
+type registration struct {
+	method string
+	path   string
+
+	// handlers are the handler arguments of the call, some of which may be middlewares
+	handlers []handlerArg
+	file     *swag.AstFileInfo
+	position token.Position
+}
+
+// handlerArg is a handler argument of a registration.
+type handlerArg struct {
+	expr    ast.Expr
+	handler handler
+}
+
+// discoverer finds the routes registered in the files of a package.
+type discoverer struct {
+	parser   *swag.Parser
+	pkgNames map[string]string
+
+	// prefixes are the path prefixes of the routers held by variables
+	prefixes map[*ast.Object]string
+
+	// methods are the methods of routes restricted by a chained call, like
+	// r.HandleFunc(path, handler).Methods("GET") of gorilla/mux
+	methods map[*ast.CallExpr][]string
+
+	// routers are the variables holding routers
+	routers map[*ast.Object]bool
+}
+
+// discoverRoutes returns the routes registered in the parsed files.
+func discoverRoutes(parser *swag.Parser) []registration {
+	d := &discoverer{
+		parser:   parser,
+		pkgNames: make(map[string]string),
+		prefixes: make(map[*ast.Object]string),
+		methods:  make(map[*ast.CallExpr][]string),
+		routers:  make(map[*ast.Object]bool),
+	}
+
+	var files []*swag.AstFileInfo
+
+	_ = parser.Packages().RangeFiles(func(info *swag.AstFileInfo) error {
+		d.pkgNames[info.PackagePath] = info.File.Name.Name
+		files = append(files, info)
+
+		return nil
+	})
+
+	var registrations []registration
+
+	for _, file := range files {
+		ast.Inspect(file.File, d.collectRouters)
+
+		ast.Inspect(file.File, func(node ast.Node) bool {
+			if call, ok := node.(*ast.CallExpr); ok {
+				registrations = append(registrations, d.registrations(file, call)...)
+			}
+
+			return true
+		})
+	}
+
+	return registrations
+}
+
+// collectRouters collects the prefixes of routers assigned to variables, the receivers of
+// registrations and the methods of chained calls.
+func (d *discoverer) collectRouters(node ast.Node) bool {
+	switch node := node.(type) {
+	case *ast.AssignStmt:
+		if len(node.Lhs) == len(node.Rhs) {
+			for i, lhs := range node.Lhs {
+				d.assign(lhs, node.Rhs[i])
+			}
+		}
+	case *ast.ValueSpec:
+		if len(node.Names) == len(node.Values) {
+			for i, name := range node.Names {
+				d.assign(name, node.Values[i])
+			}
+		}
+	case *ast.CallExpr:
+		name, receiver, ok := selectorCall(node)
+		if !ok {
+			return true
+		}
+
+		if ident, ok := receiver.(*ast.Ident); ok && ident.Obj != nil && isRouterFunc(name) {
+			d.routers[ident.Obj] = true
+		}
+
+		switch {
+		case name == "Methods":
+			if inner, ok := receiver.(*ast.CallExpr); ok {
+				for _, arg := range node.Args {
+					if method, ok := stringLiteral(arg); ok {
+						d.methods[inner] = append(d.methods[inner], strings.ToUpper(method))
+					}
+				}
+			}
+		case groupFuncs[name] && len(node.Args) > 1:
+			// the router of the prefix is the parameter of a function, like in chi
+			prefix, ok := stringLiteral(node.Args[0])
+			fn, isFunc := node.Args[len(node.Args)-1].(*ast.FuncLit)
+
+			if ok && isFunc && len(fn.Type.Params.List) > 0 && len(fn.Type.Params.List[0].Names) > 0 {
+				if param := fn.Type.Params.List[0].Names[0]; param.Obj != nil {
+					d.prefixes[param.Obj] = joinPath(d.prefix(receiver), prefix)
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func (d *discoverer) assign(lhs ast.Expr, value ast.Expr) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Obj == nil {
+		return
+	}
+
+	if _, ok := value.(*ast.CallExpr); ok {
+		if prefix := d.prefix(value); prefix != "" {
+			d.prefixes[ident.Obj] = prefix
+		}
+	}
+}
+
+// prefix returns the path prefix of the routes registered on a router.
+func (d *discoverer) prefix(router ast.Expr) string {
+	switch router := router.(type) {
+	case *ast.Ident:
+		if router.Obj != nil {
+			return d.prefixes[router.Obj]
+		}
+	case *ast.ParenExpr:
+		return d.prefix(router.X)
+	case *ast.CallExpr:
+		name, receiver, ok := selectorCall(router)
+		if !ok {
+			return ""
+		}
+
+		if groupFuncs[name] && len(router.Args) > 0 {
+			if prefix, ok := stringLiteral(router.Args[0]); ok {
+				return joinPath(d.prefix(receiver), prefix)
+			}
+		}
+
+		// r.PathPrefix(prefix).Subrouter() of gorilla/mux
+		if name == "Subrouter" {
+			return d.prefix(receiver)
+		}
+	}
+
+	return ""
+}
+
+// registrations returns the routes registered by a call, none if it registers none.
+func (d *discoverer) registrations(file *swag.AstFileInfo, call *ast.CallExpr) []registration {
+	name, receiver, ok := selectorCall(call)
+	if !ok {
+		return nil
+	}
+
+	var (
+		methods  []string
+		route    string
+		handlers []ast.Expr
+	)
+
+	switch method, isMethodFunc := methodFuncs[name]; {
+	case isMethodFunc && len(call.Args) > 1:
+		route, ok = stringLiteral(call.Args[0])
+		methods, handlers = []string{method}, call.Args[1:]
+	case handleFuncs[name] && len(call.Args) > 2:
+		method, ok = stringLiteral(call.Args[0])
+		if !ok || refRouteMethod(method) == "" {
+			return nil
+		}
+
+		route, ok = stringLiteral(call.Args[1])
+		methods, handlers = []string{refRouteMethod(method)}, call.Args[2:]
+	case handleFuncs[name] && len(call.Args) == 2:
+		var pattern string
+
+		pattern, ok = stringLiteral(call.Args[0])
+		method, route = splitPattern(pattern)
+		methods, handlers = []string{method}, call.Args[1:]
+	default:
+		return nil
+	}
+
+	if !ok || !strings.HasPrefix(route, "/") || d.mountsRouter(handlers) {
+		return nil
+	}
+
+	if chained, ok := d.methods[call]; ok {
+		methods = chained
+	}
+
+	position := d.parser.Position(file.File, call.Pos())
+	if position.Filename == "" {
+		position.Filename = file.Path
+	}
+
+	args := make([]handlerArg, 0, len(handlers))
+	for _, expr := range handlers {
+		args = append(args, handlerArg{expr: expr, handler: d.resolve(file, expr)})
+	}
+
+	registrations := make([]registration, 0, len(methods))
+	for _, method := range methods {
+		registrations = append(registrations, registration{
+			method:   method,
+			path:     joinPath(d.prefix(receiver), route),
+			handlers: args,
+			file:     file,
+			position: position,
+		})
+	}
+
+	return registrations
+}
+
+// mountsRouter reports whether the handler of a registration is a router whose routes
+// are registered on their own, like mux.Handle("/", r).
+func (d *discoverer) mountsRouter(handlers []ast.Expr) bool {
+	ident, ok := handlers[len(handlers)-1].(*ast.Ident)
+
+	return ok && ident.Obj != nil && d.routers[ident.Obj]
+}
+
+// resolve returns the function of a handler expression, with an empty name when it is
+// not a named function, like a function literal.
+func (d *discoverer) resolve(file *swag.AstFileInfo, expr ast.Expr) handler {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if expr.Obj != nil && expr.Obj.Kind == ast.Var && !isTopLevel(file.File, expr.Obj) {
+			return handler{}
+		}
+
+		return handler{pkgPath: file.PackagePath, name: expr.Name}
+	case *ast.SelectorExpr:
+		if ident, ok := expr.X.(*ast.Ident); ok && ident.Obj == nil {
+			if pkgPath, ok := d.importPath(file.File, ident.Name); ok {
+				return handler{pkgPath: pkgPath, name: expr.Sel.Name}
+			}
+		}
+
+		// a method value, like h.GetUser
+		return handler{name: expr.Sel.Name}
+	case *ast.ParenExpr:
+		return d.resolve(file, expr.X)
+	case *ast.CallExpr:
+		// a conversion like http.HandlerFunc(GetUser), or a function returning the handler
+		if name, _, ok := selectorCall(expr); ok && name == "HandlerFunc" && len(expr.Args) == 1 {
+			return d.resolve(file, expr.Args[0])
+		}
+
+		return d.resolve(file, expr.Fun)
+	}
+
+	return handler{}
+}
+
+// importPath returns the path of the package imported with a name by a file.
+func (d *discoverer) importPath(file *ast.File, name string) (string, bool) {
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		importName := d.pkgNames[importPath]
+		if imp.Name != nil {
+			importName = imp.Name.Name
+		} else if importName == "" {
+			importName = path.Base(importPath)
+			if strings.HasPrefix(importName, "v") && len(importName) > 1 && strings.Trim(importName[1:], "0123456789") == "" {
+				importName = path.Base(path.Dir(importPath))
+			}
+		}
+
+		if importName == name {
+			return importPath, true
+		}
+	}
+
+	return "", false
+}
+
+// documentedHandler returns the function documented by the comments of an operation.
+func documentedHandler(parsed swag.ParsedOperation) handler {
+	if len(parsed.Comments) == 0 {
+		return handler{}
+	}
+
+	first := parsed.Comments[0]
+
+	isDoc := func(doc *ast.CommentGroup) bool {
+		return doc != nil && len(doc.List) > 0 && doc.List[0] == first
+	}
+
+	for _, decl := range parsed.File.File.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !isDoc(decl.Doc) {
+				continue
+			}
+
+			name := decl.Name.Name
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				name = receiverName(decl.Recv.List[0].Type) + "." + name
+			}
+
+			return handler{pkgPath: parsed.File.PackagePath, name: name}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if ok && (isDoc(decl.Doc) || isDoc(valueSpec.Doc)) && len(valueSpec.Names) > 0 {
+					return handler{pkgPath: parsed.File.PackagePath, name: valueSpec.Names[0].Name}
+				}
+			}
+		}
+	}
+
+	return handler{}
+}
+
+func receiverName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(expr.X)
+	case *ast.IndexExpr:
+		return receiverName(expr.X)
+	case *ast.IndexListExpr:
+		return receiverName(expr.X)
+	}
+
+	return types.ExprString(expr)
+}
+
+func isTopLevel(file *ast.File, obj *ast.Object) bool {
+	return file.Scope != nil && file.Scope.Lookup(obj.Name) == obj
+}
+
+// isRouterFunc reports whether a function called on a router registers routes or returns
+// a router of a prefix.
+func isRouterFunc(name string) bool {
+	_, isMethodFunc := methodFuncs[name]
+
+	return isMethodFunc || handleFuncs[name] || groupFuncs[name]
+}
+
+// selectorCall returns the name of the function called on a receiver, like GET for
+// r.GET(path, handler).
+func selectorCall(call *ast.CallExpr) (string, ast.Expr, bool) {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", nil, false
+	}
+
+	return selector.Sel.Name, selector.X, true
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+
+	value, err := strconv.Unquote(literal.Value)
+
+	return value, err == nil
+}
+
+// refRouteMethod returns the upper case HTTP method, empty if it is none.
+func refRouteMethod(method string) string {
+	method = strings.ToUpper(method)
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return method
+	}
+
+	return ""
+}
+
+// splitPattern returns the method and the path of a net/http pattern, like
+// "GET example.com/users/{id}", the method being empty when the pattern has none.
+func splitPattern(pattern string) (string, string) {
+	var method string
+
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method, pattern = refRouteMethod(pattern[:i]), strings.TrimSpace(pattern[i:])
+	}
+
+	if i := strings.Index(pattern, "/"); i > 0 {
+		// the host of the pattern
+		pattern = pattern[i:]
+	}
+
+	return method, pattern
+}
+
+func joinPath(prefix, route string) string {
+	if prefix == "" {
+		return route
+	}
+
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(route, "/")
+}
+
+// normalizePath returns the path with the parameters of every router syntax, like :id,
+// *path, {id} or {id:[0-9]+}, written as {} and without trailing slash.
+func normalizePath(route string) string {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+
+	normalized := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		switch {
+		case segment == "{$}":
+			continue
+		case strings.HasPrefix(segment, ":"), strings.HasPrefix(segment, "*"),
+			strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			segment = "{}"
+		}
+
+		normalized = append(normalized, segment)
+	}
+
+	return "/" + strings.Join(normalized, "/")
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/swaggo/swag"
+)
+
+func TestDiscoverRoutes(t *testing.T) {
+	src := `package api
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/mux"
+	"github.com/labstack/echo/v4"
+)
+
+func chiRoutes(r chi.Router) {
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/{id}", GetUser)
+		r.Method("PUT", "/{id}", http.HandlerFunc(UpdateUser))
+	})
+}
+
+func muxRoutes() {
+	r := mux.NewRouter()
+	api := r.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/users/{id}", GetUser).Methods("GET", "HEAD")
+	http.Handle("/", r)
+}
+
+func echoRoutes(e *echo.Echo) {
+	g := e.Group("/v1")
+	g.Add("DELETE", "/users/:id", DeleteUser, middleware)
+	g.Any("/files/*", files.Serve())
+	cache.Get("key", nil)
+}
+`
+
+	parser := swag.New()
+	require.NoError(t, parser.Packages().ParseFile("api", "api/api.go", src, swag.ParseAll))
+
+	var routes []string
+	for _, reg := range discoverRoutes(parser) {
+		handler := reg.handlers[len(reg.handlers)-1].handler
+		routes = append(routes, reg.method+" "+reg.path+" "+handler.pkgPath+" "+handler.name)
+	}
+
+	assert.Equal(t, []string{
+		"GET /users/{id} api GetUser",
+		"PUT /users/{id} api UpdateUser",
+		"GET /api/users/{id} api GetUser",
+		"HEAD /api/users/{id} api GetUser",
+		"DELETE /v1/users/:id api middleware",
+		" /v1/files/*  Serve",
+	}, routes)
+}
+
+func TestHandler_Matches(t *testing.T) {
+	documented := handler{pkgPath: "api", name: "Handler.GetUser"}
+
+	assert.True(t, handler{pkgPath: "api", name: "Handler.GetUser"}.matches(documented))
+	assert.True(t, handler{name: "GetUser"}.matches(documented))
+	assert.False(t, handler{pkgPath: "other", name: "Handler.GetUser"}.matches(documented))
+	assert.False(t, handler{name: "User"}.matches(documented))
+	assert.False(t, handler{}.matches(handler{}))
+}
+
+func TestSplitPattern(t *testing.T) {
+	tests := []struct {
+		pattern, method, path string
+	}{
+		{"/users/", "", "/users/"},
+		{"GET /users/{id}", "GET", "/users/{id}"},
+		{"POST example.com/users", "POST", "/users"},
+		{"example.com/", "", "/"},
+	}
+
+	for _, tt := range tests {
+		method, path := splitPattern(tt.pattern)
+		assert.Equal(t, tt.method, method, tt.pattern)
+		assert.Equal(t, tt.path, path, tt.pattern)
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	assert.Equal(t, "/users/{}", normalizePath("/users/:id"))
+	assert.Equal(t, "/users/{}", normalizePath("/users/{id}/"))
+	assert.Equal(t, "/users/{}", normalizePath("/users/{id:[0-9]+}"))
+	assert.Equal(t, "/files/{}", normalizePath("/files/*path"))
+	assert.Equal(t, "/files/{}", normalizePath("/files/{path...}"))
+	assert.Equal(t, "/", normalizePath("/{$}"))
+}
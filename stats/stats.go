@@ -0,0 +1,340 @@
+// Package stats reports how much of the routes registered by an API are documented with
+// swag comments.
+package stats
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/swaggo/swag"
+	"github.com/swaggo/swag/gen"
+)
+
+// Route is a route registered by a call to a router, like r.GET("/users/:id", GetUser).
+type Route struct {
+	// Method is the HTTP method of the route, empty when it matches every method
+	Method string
+
+	// Path is the path of the route, prefixed with the ones of its router groups
+	Path string
+
+	// Handler is the handler expression of the registration
+	Handler string
+
+	// Package is the import path of the package of the handler
+	Package string
+
+	// Position is the position of the registration
+	Position token.Position
+
+	// Documented tells whether the handler or the route is documented by an operation
+	Documented bool
+}
+
+// String returns the route as file:line:column: METHOD path handler.
+func (route Route) String() string {
+	method := route.Method
+	if method == "" {
+		method = "ANY"
+	}
+
+	return fmt.Sprintf("%s: %s %s %s", route.Position, method, route.Path, route.Handler)
+}
+
+// Operation is a route documented by swag comments.
+type Operation struct {
+	// Method is the HTTP method of the @Router
+	Method string
+
+	// Path is the path of the @Router, without the base path
+	Path string
+
+	// Handler is the documented function, empty when the comments document none
+	Handler string
+
+	// Position is the position of the @Router comment
+	Position token.Position
+}
+
+// String returns the operation as file:line:column: METHOD path handler.
+func (op Operation) String() string {
+	return strings.TrimSpace(fmt.Sprintf("%s: %s %s %s", op.Position, op.Method, op.Path, op.Handler))
+}
+
+// PackageCoverage is the coverage of the routes whose handlers are in a package.
+type PackageCoverage struct {
+	Package    string
+	Routes     int
+	Documented int
+}
+
+// Percent returns the percentage of the documented routes, 100 when there are none.
+func (coverage PackageCoverage) Percent() float64 {
+	return percent(coverage.Documented, coverage.Routes)
+}
+
+// Report is the coverage of the routes of an API by its swag comments.
+type Report struct {
+	// Routes are the registered routes, sorted by position
+	Routes []Route
+
+	// Unregistered are the documented routes neither registered nor whose handler is,
+	// sorted by position
+	Unregistered []Operation
+}
+
+// Undocumented returns the registered routes which are not documented.
+func (report *Report) Undocumented() []Route {
+	var routes []Route
+
+	for _, route := range report.Routes {
+		if !route.Documented {
+			routes = append(routes, route)
+		}
+	}
+
+	return routes
+}
+
+// Packages returns the coverage of the packages of the handlers, sorted by package.
+func (report *Report) Packages() []PackageCoverage {
+	byPackage := make(map[string]*PackageCoverage)
+
+	for _, route := range report.Routes {
+		coverage, ok := byPackage[route.Package]
+		if !ok {
+			coverage = &PackageCoverage{Package: route.Package}
+			byPackage[route.Package] = coverage
+		}
+
+		coverage.Routes++
+		if route.Documented {
+			coverage.Documented++
+		}
+	}
+
+	packages := make([]PackageCoverage, 0, len(byPackage))
+	for _, coverage := range byPackage {
+		packages = append(packages, *coverage)
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		return packages[i].Package < packages[j].Package
+	})
+
+	return packages
+}
+
+// Coverage returns the percentage of the documented routes, 100 when there are none.
+func (report *Report) Coverage() float64 {
+	documented := 0
+
+	for _, route := range report.Routes {
+		if route.Documented {
+			documented++
+		}
+	}
+
+	return percent(documented, len(report.Routes))
+}
+
+func percent(n, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+
+	return float64(n) * 100 / float64(total)
+}
+
+// Config specifies the API to report on.
+type Config struct {
+	// Project is the API to report on
+	gen.Project
+}
+
+// Reporter matches the routes registered by an API with its operations.
+type Reporter struct{}
+
+// New creates a new Reporter.
+func New() *Reporter {
+	return &Reporter{}
+}
+
+// Report parses the API of config, discovers the routes registered by calls to routers
+// like the ones of net/http, gin, echo, chi, fiber and gorilla/mux, and matches them with
+// the operations by handler or by method and path.
+func (*Reporter) Report(config *Config) (*Report, error) {
+	parser, err := config.Parse(swag.SetDebugger(quietDebugger{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return report(parser), nil
+}
+
+// operation is a parsed operation with the function it documents.
+type operation struct {
+	swag.ParsedOperation
+
+	handler  handler
+	position token.Position
+}
+
+func report(parser *swag.Parser) *Report {
+	basePath := parser.GetSwagger().BasePath
+
+	var operations []*operation
+
+	for _, parsed := range parser.ParsedOperations() {
+		if len(parsed.RouterProperties) == 0 {
+			continue
+		}
+
+		op := &operation{ParsedOperation: parsed, handler: documentedHandler(parsed)}
+		op.position = parser.Position(parsed.File.File, parsed.Comments[0].Pos())
+
+		for _, comment := range parsed.Comments {
+			if strings.Contains(strings.ToLower(comment.Text), "@router") {
+				op.position = parser.Position(parsed.File.File, comment.Pos())
+			}
+		}
+
+		operations = append(operations, op)
+	}
+
+	// the operations of the routes, by method and normalized path, with and without the
+	// base path
+	documentedRoutes := make(map[string]*operation)
+
+	for _, op := range operations {
+		for _, properties := range op.RouterProperties {
+			documentedRoutes[properties.HTTPMethod+" "+normalizePath(properties.Path)] = op
+			documentedRoutes[properties.HTTPMethod+" "+normalizePath(joinPath(basePath, properties.Path))] = op
+		}
+	}
+
+	registered := make(map[*operation]bool)
+
+	report := &Report{}
+
+	for _, reg := range discoverRoutes(parser) {
+		arg, documented := documentedHandlerArg(reg, operations)
+		if documented == nil {
+			documented = documentedRoute(documentedRoutes, reg.method, reg.path)
+		}
+
+		route := Route{
+			Method:   reg.method,
+			Path:     reg.path,
+			Handler:  types.ExprString(arg.expr),
+			Package:  reg.file.PackagePath,
+			Position: reg.position,
+		}
+
+		if arg.handler.pkgPath != "" {
+			route.Package = arg.handler.pkgPath
+		}
+
+		if documented != nil {
+			route.Documented, route.Package = true, documented.File.PackagePath
+			registered[documented] = true
+		}
+
+		report.Routes = append(report.Routes, route)
+	}
+
+	for _, op := range operations {
+		if registered[op] {
+			continue
+		}
+
+		for _, properties := range op.RouterProperties {
+			report.Unregistered = append(report.Unregistered, Operation{
+				Method:   properties.HTTPMethod,
+				Path:     properties.Path,
+				Handler:  op.handlerName(),
+				Position: op.position,
+			})
+		}
+	}
+
+	sort.SliceStable(report.Routes, func(i, j int) bool {
+		return less(report.Routes[i].Position, report.Routes[j].Position)
+	})
+	sort.SliceStable(report.Unregistered, func(i, j int) bool {
+		return less(report.Unregistered[i].Position, report.Unregistered[j].Position)
+	})
+
+	return report
+}
+
+// documentedHandlerArg returns the handler argument of a registration documented by an
+// operation, or the last one which is not a call when none is, the others being
+// middlewares like gin.Logger().
+func documentedHandlerArg(reg registration, operations []*operation) (handlerArg, *operation) {
+	for _, arg := range reg.handlers {
+		for _, op := range operations {
+			if arg.handler.matches(op.handler) {
+				return arg, op
+			}
+		}
+	}
+
+	for i := len(reg.handlers) - 1; i >= 0; i-- {
+		if _, isCall := reg.handlers[i].expr.(*ast.CallExpr); !isCall {
+			return reg.handlers[i], nil
+		}
+	}
+
+	return reg.handlers[len(reg.handlers)-1], nil
+}
+
+// documentedRoute returns the operation of a route, of any method when the route matches
+// every method, nil if there is none.
+func documentedRoute(documentedRoutes map[string]*operation, method, path string) *operation {
+	methods := []string{method}
+	if method == "" {
+		methods = []string{
+			http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+			http.MethodDelete, http.MethodHead, http.MethodOptions,
+		}
+	}
+
+	for _, method := range methods {
+		if op, ok := documentedRoutes[method+" "+normalizePath(path)]; ok {
+			return op
+		}
+	}
+
+	return nil
+}
+
+// handlerName returns the name of the documented function, like users.GetUser.
+func (op *operation) handlerName() string {
+	if op.handler.name == "" {
+		return ""
+	}
+
+	return op.File.File.Name.Name + "." + op.handler.name
+}
+
+func less(a, b token.Position) bool {
+	if a.Filename != b.Filename {
+		return a.Filename < b.Filename
+	}
+
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+
+	return a.Column < b.Column
+}
+
+type quietDebugger struct{}
+
+func (quietDebugger) Printf(string, ...any) {}
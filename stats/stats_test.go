@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/swaggo/swag/gen"
+)
+
+const searchDir = "../testdata/stats"
+
+func TestReporter_Report(t *testing.T) {
+	report, err := New().Report(&Config{Project: gen.Project{SearchDir: searchDir, MainAPIFile: "main.go", ParseDepth: 100}})
+	require.NoError(t, err)
+
+	var routes []string
+	for _, route := range report.Routes {
+		routes = append(routes, route.String())
+	}
+
+	assert.Equal(t, []string{
+		"../testdata/stats/main.go:21:3: GET /api/users/:id users.GetUser",
+		"../testdata/stats/main.go:22:3: POST /api/users users.CreateUser",
+		"../testdata/stats/main.go:23:3: PUT /api/users/:id h.UpdateUser",
+		"../testdata/stats/main.go:24:3: DELETE /api/users/:id users.DeleteUser",
+		"../testdata/stats/main.go:28:2: GET /api/admin/stats (func(c *router.Context) literal)",
+		"../testdata/stats/main.go:31:2: GET /api/health health.Check",
+		"../testdata/stats/main.go:32:2: ANY /api/orders/{id} orders.Handler",
+	}, routes)
+
+	var undocumented []string
+	for _, route := range report.Undocumented() {
+		undocumented = append(undocumented, route.Handler)
+	}
+
+	assert.Equal(t, []string{"users.CreateUser", "(func(c *router.Context) literal)", "orders.Handler"}, undocumented)
+
+	require.Len(t, report.Unregistered, 1)
+	assert.Equal(t, "../testdata/stats/users/users.go:17:1: GET /users users.ListUsers", report.Unregistered[0].String())
+
+	assert.Equal(t, []PackageCoverage{
+		{Package: "github.com/swaggo/swag/testdata/stats", Routes: 1, Documented: 0},
+		{Package: "github.com/swaggo/swag/testdata/stats/health", Routes: 1, Documented: 1},
+		{Package: "github.com/swaggo/swag/testdata/stats/orders", Routes: 1, Documented: 0},
+		{Package: "github.com/swaggo/swag/testdata/stats/users", Routes: 4, Documented: 3},
+	}, report.Packages())
+	assert.InDelta(t, 75, report.Packages()[3].Percent(), 0.01)
+	assert.InDelta(t, 57.14, report.Coverage(), 0.01)
+}
+
+func TestReport_CoverageWithoutRoutes(t *testing.T) {
+	report := &Report{}
+
+	assert.Equal(t, float64(100), report.Coverage())
+	assert.Empty(t, report.Packages())
+}
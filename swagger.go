@@ -3,6 +3,7 @@ package swag
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -48,6 +49,21 @@ func GetSwagger(name string) Swagger {
 	return swags[name]
 }
 
+// RegisteredNames returns the sorted names of the registered swagger instances.
+func RegisteredNames() []string {
+	swaggerMu.RLock()
+	defer swaggerMu.RUnlock()
+
+	names := make([]string, 0, len(swags))
+	for name := range swags {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
 // ReadDoc reads swagger document. An optional name parameter can be passed to read a specific document.
 // The default name is "swagger".
 func ReadDoc(optionalName ...string) (string, error) {
@@ -0,0 +1,319 @@
+package swag
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	templateAttr = "@template"
+	useAttr      = "@use"
+)
+
+// operationTemplate is a named block of operation annotations, applied to operations
+// with @Use.
+type operationTemplate struct {
+	name  string
+	lines []string
+
+	// file is the ast.File the template is declared in, where its types are resolved
+	file *ast.File
+}
+
+// templateBlockLines reports which of the comment lines belong to a template block. A
+// block starts with @Template name and ends before the next blank line.
+func templateBlockLines(lines []string) []bool {
+	inBlock := make([]bool, len(lines))
+	block := false
+
+	for i, line := range lines {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "/"))
+		if line == "" {
+			block = false
+
+			continue
+		}
+
+		if strings.ToLower(FieldsByAnySpace(line, 2)[0]) == templateAttr {
+			block = true
+		}
+
+		inBlock[i] = block
+	}
+
+	return inBlock
+}
+
+// collectTemplates registers the templates declared in the comments of the file.
+func (parser *Parser) collectTemplates(fileInfo *AstFileInfo) error {
+	if (fileInfo.ParseFlag & ParseOperations) == ParseNone {
+		return nil
+	}
+
+	for _, commentGroup := range fileInfo.File.Comments {
+		lines := make([]string, 0, len(commentGroup.List))
+		for _, comment := range commentGroup.List {
+			lines = append(lines, strings.TrimSpace(strings.TrimLeft(comment.Text, "/")))
+		}
+
+		var template *operationTemplate
+
+		for i, inBlock := range templateBlockLines(lines) {
+			if !inBlock {
+				template = nil
+
+				continue
+			}
+
+			fields := FieldsByAnySpace(lines[i], 2)
+			if strings.ToLower(fields[0]) != templateAttr {
+				// the indentation of blocks is kept
+				template.lines = append(template.lines, commentGroup.List[i].Text)
+
+				continue
+			}
+
+			if len(fields) != 2 || strings.ContainsAny(fields[1], " \t,") {
+				return fmt.Errorf("%s needs a single name in file %s", fields[0], fileInfo.Path)
+			}
+
+			if _, ok := parser.templates[fields[1]]; ok {
+				return fmt.Errorf("template %s is declared multiple times", fields[1])
+			}
+
+			template = &operationTemplate{name: fields[1], file: fileInfo.File}
+			parser.templates[template.name] = template
+		}
+	}
+
+	return nil
+}
+
+// ParseUseComment parses the names of the templates applied to the operation, separated
+// by spaces or commas. E.g. @Use authed paginated.
+func (operation *Operation) ParseUseComment(commentLine string) error {
+	names := splitTemplateNames(commentLine)
+	if len(names) == 0 {
+		return fmt.Errorf("@Use needs a template name")
+	}
+
+	for _, name := range names {
+		if _, ok := operation.parser.templates[name]; !ok {
+			return fmt.Errorf("template %s is not defined, declare it with @Template", name)
+		}
+
+		operation.templates = append(operation.templates, name)
+	}
+
+	return nil
+}
+
+// splitTemplateNames splits the names of a @Use comment, separated by spaces or commas.
+func splitTemplateNames(names string) []string {
+	return strings.FieldsFunc(names, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+}
+
+// applyTemplates completes the operation with the annotations of the templates it uses,
+// the ones of the operation taking precedence. The response headers of templates apply
+// to the responses of the operation.
+func (operation *Operation) applyTemplates() error {
+	headers, err := operation.inheritTemplates(nil)
+	if err != nil {
+		return err
+	}
+
+	for _, header := range headers {
+		err = operation.inheritHeader(header)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inheritTemplates inherits the templates used by the operation, and returns their
+// @Header lines, the ones of outer templates first.
+func (operation *Operation) inheritTemplates(using []string) ([]string, error) {
+	var headers []string
+
+	for _, name := range operation.templates {
+		for _, used := range using {
+			if used == name {
+				return nil, fmt.Errorf("template %s uses itself", name)
+			}
+		}
+
+		template := operation.parser.templates[name]
+
+		templateOperation, templateHeaders, err := operation.parseTemplateLines(template.lines, template.file)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+
+		nested, err := templateOperation.inheritTemplates(append(using, name))
+		if err != nil {
+			return nil, err
+		}
+
+		headers = append(headers, templateHeaders...)
+		headers = append(headers, nested...)
+
+		operation.inherit(&templateOperation.Operation)
+	}
+
+	return headers, nil
+}
+
+// parseTemplateLines parses annotation lines shared by several operations, and returns
+// their @Header lines apart since they apply to the responses of the operations.
+func (operation *Operation) parseTemplateLines(lines []string, file *ast.File) (*Operation, []string, error) {
+	var headers []string
+
+	templateOperation := NewOperation(operation.parser, SetCodeExampleFilesDirectory(operation.codeExampleFilesDir))
+
+	lines, _ = joinBlockLines(lines)
+	for _, line := range lines {
+		commentLine := strings.TrimSpace(strings.TrimLeft(line, "/"))
+		if fields := FieldsByAnySpace(commentLine, 2); strings.ToLower(fields[0]) == headerAttr {
+			headers = append(headers, strings.Join(fields[1:], ""))
+
+			continue
+		}
+
+		err := templateOperation.ParseComment(line, file)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return templateOperation, headers, nil
+}
+
+// inheritHeader adds the response header of a template to the responses of the
+// operation which do not declare it.
+func (operation *Operation) inheritHeader(commentLine string) error {
+	headerOperation := NewOperation(operation.parser)
+	if operation.Responses.Default != nil {
+		headerOperation.DefaultResponse()
+	}
+
+	for code := range operation.Responses.StatusCodeResponses {
+		headerOperation.AddResponse(code, spec.NewResponse())
+	}
+
+	err := headerOperation.ParseResponseHeaderComment(commentLine, nil)
+	if err != nil {
+		return err
+	}
+
+	if headerOperation.Responses.Default != nil {
+		inheritHeaders(operation.Responses.Default, headerOperation.Responses.Default.Headers)
+	}
+
+	for code, response := range headerOperation.Responses.StatusCodeResponses {
+		target := operation.Responses.StatusCodeResponses[code]
+		inheritHeaders(&target, response.Headers)
+		operation.Responses.StatusCodeResponses[code] = target
+	}
+
+	return nil
+}
+
+func inheritHeaders(response *spec.Response, headers map[string]spec.Header) {
+	if response.Ref.String() != "" {
+		return
+	}
+
+	for key, header := range headers {
+		if _, ok := response.Headers[key]; ok {
+			continue
+		}
+
+		if response.Headers == nil {
+			response.Headers = make(map[string]spec.Header)
+		}
+
+		response.Headers[key] = header
+	}
+}
+
+// inherit copies the properties of template which the operation does not set.
+func (operation *Operation) inherit(template *spec.Operation) {
+	if operation.Summary == "" {
+		operation.Summary = template.Summary
+	}
+
+	if operation.Description == "" {
+		operation.Description = template.Description
+	}
+
+	if operation.ExternalDocs == nil {
+		operation.ExternalDocs = template.ExternalDocs
+	}
+
+	for _, tag := range template.Tags {
+		if !findInSlice(operation.Tags, tag) {
+			operation.Tags = append(operation.Tags, tag)
+		}
+	}
+
+	if len(operation.Consumes) == 0 {
+		operation.Consumes = template.Consumes
+	}
+
+	if len(operation.Produces) == 0 {
+		operation.Produces = template.Produces
+	}
+
+	if operation.Security == nil {
+		operation.Security = template.Security
+	}
+
+	for _, param := range template.Parameters {
+		if !hasParameter(operation.Parameters, param) {
+			operation.Operation.Parameters = append(operation.Operation.Parameters, param)
+		}
+	}
+
+	if operation.Responses.Default == nil {
+		operation.Responses.Default = template.Responses.Default
+	}
+
+	for code, response := range template.Responses.StatusCodeResponses {
+		if _, ok := operation.Responses.StatusCodeResponses[code]; !ok {
+			operation.Responses.StatusCodeResponses[code] = response
+		}
+	}
+
+	for key, value := range template.Extensions {
+		if _, ok := operation.Extensions[key]; !ok {
+			operation.Extensions[key] = value
+		}
+	}
+
+	operation.Deprecated = operation.Deprecated || template.Deprecated
+}
+
+func hasParameter(params []spec.Parameter, param spec.Parameter) bool {
+	for _, p := range params {
+		if p.Ref.String() != "" || param.Ref.String() != "" {
+			if p.Ref.String() == param.Ref.String() {
+				return true
+			}
+
+			continue
+		}
+
+		if p.Name == param.Name && p.In == param.In {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package swag
+
+import (
+	"encoding/json"
+	goparser "go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplates(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/templates"
+	p := New()
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+}
+
+func TestTemplateBlockLines(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []bool{false, true, true, false, false}, templateBlockLines([]string{
+		"// @Summary Get",
+		"// @Template authed",
+		"// @Security ApiKeyAuth",
+		"//",
+		"// @Router /a [get]",
+	}))
+}
+
+func collectTestTemplates(t *testing.T, p *Parser, src string) error {
+	t.Helper()
+
+	f, err := goparser.ParseFile(token.NewFileSet(), "api.go", src, goparser.ParseComments)
+	require.NoError(t, err)
+
+	return p.collectTemplates(&AstFileInfo{File: f, Path: "api.go", ParseFlag: ParseAll})
+}
+
+func TestOperation_ParseUseComment(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	require.NoError(t, collectTestTemplates(t, p, `package api
+
+// @Template authed
+// @Security ApiKeyAuth
+// @Produce json
+// @Param X-Request-ID header string false "Request ID"
+// @Failure 401,500 {string} string "Error"
+// @Header all {string} X-Request-ID "Request ID"
+
+// @Template recursive
+// @Use recursive
+`))
+
+	operation := NewOperation(p)
+	for _, comment := range []string{
+		`@Use authed`,
+		`@Param X-Request-ID header int true "Overridden"`,
+		`@Success 200 {string} string "OK"`,
+		`@Failure 500 {object} string "Local"`,
+		`@Header 200 {integer} X-Request-ID "Local"`,
+	} {
+		require.NoError(t, operation.ParseComment(comment, nil))
+	}
+
+	require.NoError(t, operation.applyTemplates())
+
+	assert.Equal(t, []map[string][]string{{"ApiKeyAuth": {}}}, operation.Security)
+	assert.Equal(t, []string{"application/json"}, operation.Produces)
+	require.Len(t, operation.Parameters, 1)
+	assert.Equal(t, "Overridden", operation.Parameters[0].Description)
+	assert.Equal(t, "Error", operation.Responses.StatusCodeResponses[401].Description)
+	assert.Equal(t, "Local", operation.Responses.StatusCodeResponses[500].Description)
+	assert.Equal(t, "Local", operation.Responses.StatusCodeResponses[200].Headers["X-Request-ID"].Description)
+	assert.Equal(t, "Request ID", operation.Responses.StatusCodeResponses[500].Headers["X-Request-ID"].Description)
+
+	assert.EqualError(t, NewOperation(p).ParseComment(`@Use missing`, nil), "template missing is not defined, declare it with @Template")
+	assert.EqualError(t, NewOperation(p).ParseComment(`@Use`, nil), "@Use needs a template name")
+
+	operation = NewOperation(p)
+	require.NoError(t, operation.ParseComment(`@Use recursive`, nil))
+	assert.EqualError(t, operation.applyTemplates(), "template recursive uses itself")
+
+	assert.EqualError(t, collectTestTemplates(t, p, `package api
+
+// @Template authed
+`), "template authed is declared multiple times")
+	assert.EqualError(t, collectTestTemplates(t, New(), `package api
+
+// @Template
+`), "@Template needs a single name in file api.go")
+}
+
+func TestParseGeneralAPIInfoWithTemplate(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	assert.NoError(t, parseGeneralAPIInfo(p, []string{
+		"@title Templates",
+		"@Template authed",
+		"@Security ApiKeyAuth",
+		"@Failure 401 {string} string \"Unauthorized\"",
+		"",
+		"@version 1.0",
+	}))
+	assert.True(t, isGeneralAPIComment([]string{"@Template authed", "@Failure 401 {string} string \"Unauthorized\""}))
+	assert.Equal(t, "Templates", p.swagger.Info.Title)
+	assert.Equal(t, "1.0", p.swagger.Info.Version)
+	assert.Empty(t, p.swagger.Security)
+}
@@ -0,0 +1,33 @@
+package api
+
+import "net/http"
+
+// User is a user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListUsers lists the users.
+//
+//	@Summary		List the users
+//	@Description	|
+//	  Lists the users, **by name**:
+//
+//	  - the active ones first
+//	  - then the others
+//
+//	      indented "code"
+//	@Use			paginated
+//	@Param			name	query	string	false	|
+//	  The name of the users,
+//	  or a prefix of it.
+//	@Param			role	query	string	false	"The role"	enums(admin,user)
+//	@Success		200		{array}	User	|
+//	  The users, possibly none.
+//	@Success		206		{array}	User	mime(xml)	|
+//	  A range of the users, as XML.
+//	@Failure		400		|
+//	  The name is invalid.
+//	@Router			/users [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
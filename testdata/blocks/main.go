@@ -0,0 +1,20 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/blocks/api"
+)
+
+// @title Swagger Blocks API
+// @version 1.0
+// @BasePath /api
+
+// @Template paginated
+// @Param page query int false |
+//   The page of the results, from 1.
+//   The last page is empty.
+func main() {
+	http.HandleFunc("/api/users", api.ListUsers)
+	http.ListenAndServe(":8080", nil)
+}
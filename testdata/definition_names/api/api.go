@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/definition_names/response"
+	v1 "github.com/swaggo/swag/testdata/definition_names/v1/model"
+	v2 "github.com/swaggo/swag/testdata/definition_names/v2/model"
+)
+
+// ListUsersV1 lists the users.
+//
+//	@Success	200	{object}	response.Page[v1.User]
+//	@Success	206	{object}	response.Page[[]v1.Role]
+//	@Failure	400	{object}	response.Error
+//	@Router		/v1/users [get]
+func ListUsersV1(w http.ResponseWriter, r *http.Request) {}
+
+// ListUsersV2 lists the users.
+//
+//	@Success	200	{object}	response.Page[map[string]v2.User]
+//	@Success	206	{object}	api.ListUsersV2.Filter
+//	@Router		/v2/users [get]
+func ListUsersV2(w http.ResponseWriter, r *http.Request) {
+	// Filter is a filter of the users.
+	type Filter struct {
+		Name string `json:"name"`
+	}
+
+	_ = Filter{}
+}
+
+// GetUsers returns the users of both versions.
+//
+//	@Success	200	{array}	v1.User
+//	@Success	206	{array}	v2.User
+//	@Router		/users [get]
+func GetUsers(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/definition_names/api"
+)
+
+// @title Swagger Definition Names API
+// @version 1.0
+// @BasePath /api
+func main() {
+	http.HandleFunc("/v1/users", api.ListUsersV1)
+	http.HandleFunc("/v2/users", api.ListUsersV2)
+	http.ListenAndServe(":8080", nil)
+}
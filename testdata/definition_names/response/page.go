@@ -0,0 +1,12 @@
+package response
+
+// Page is a page of items.
+type Page[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+// Error is an error of the API.
+type Error struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,12 @@
+package model
+
+// User is a user of the first version of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Role is the role of a user.
+type Role struct {
+	Name string `json:"name"`
+} // @name UserRole
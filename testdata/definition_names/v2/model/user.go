@@ -0,0 +1,7 @@
+package model
+
+// User is a user of the second version of the API.
+type User struct {
+	ID       string `json:"id"`
+	FullName string `json:"fullName"`
+}
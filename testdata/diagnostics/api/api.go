@@ -0,0 +1,29 @@
+package api
+
+import "net/http"
+
+// User is a user of the API.
+type User struct {
+	ID int `json:"id"`
+}
+
+// ListUsers misspells an attribute.
+//
+// @Summary List users
+// @Sucess 200 {array} api.User
+// @Router /users [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+// GetUser references an unknown type.
+//
+// @Summary Get a user
+// @Param id path int true "User ID"
+// @Success 200 {object} api.Member
+// @Router /users/{id} [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser has an invalid method.
+//
+// @Summary Delete a user
+// @Router /users/{id} [remove]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/diagnostics/api"
+)
+
+// @title Swagger Diagnostics API
+// @version 1.0
+// @BasePath /api
+func main() {
+	http.HandleFunc("/api/users", api.ListUsers)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,28 @@
+package api
+
+import "net/http"
+
+type Public struct {
+	Name string `json:"name"`
+}
+
+type Secret struct {
+	Token string `json:"token"`
+}
+
+// GetPublic returns the public data.
+//
+// @Summary Get the public data
+// @Tags public
+// @Success 200 {object} api.Public
+// @Router /public [get]
+func GetPublic(w http.ResponseWriter, r *http.Request) {}
+
+// GetInternal returns the internal data.
+//
+// @Summary Get the internal data
+// @Tags internal
+// @Param filter query badtype false "Filter"
+// @Success 200 {object} api.Secret
+// @Router /internal [get]
+func GetInternal(w http.ResponseWriter, r *http.Request) {}
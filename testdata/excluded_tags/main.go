@@ -0,0 +1,16 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/excluded_tags/api"
+)
+
+// @title Swagger Excluded Tags API
+// @version 1.0
+// @BasePath /api
+func main() {
+	http.HandleFunc("/api/public", api.GetPublic)
+	http.HandleFunc("/api/internal", api.GetInternal)
+	http.ListenAndServe(":8080", nil)
+}
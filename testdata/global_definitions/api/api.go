@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	_ "github.com/swaggo/swag/testdata/global_definitions/web"
+)
+
+// ListPets godoc
+// @Summary List the pets
+// @Produce json
+// @Param $ref:pagination.page
+// @Param $ref:pagination.size
+// @Param $ref:requestID
+// @Param name query string false "Filter by name"
+// @Success 200 {array} web.Pet
+// @Failure 500 $ref:InternalError
+// @Router /pets [get]
+func ListPets(w http.ResponseWriter, r *http.Request) {}
+
+// DeletePet godoc
+// @Summary Delete a pet
+// @Param id path int true "Pet ID"
+// @Param $ref:requestID
+// @Success 204 $ref:NoContent
+// @Failure 404 $ref:NotFound
+// @Failure 500,default $ref:InternalError
+// @Router /pets/{id} [delete]
+func DeletePet(w http.ResponseWriter, r *http.Request) {}
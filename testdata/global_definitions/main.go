@@ -0,0 +1,24 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/global_definitions/api"
+)
+
+// @title Swagger Global Definitions API
+// @version 1.0
+// @description Parameters and responses defined once and referenced by the operations.
+// @BasePath /api/v1
+
+// @param.define pagination.page page query int false "Page number" default(1) minimum(1)
+// @param.define pagination.size size query int false "Page size" default(20) maximum(100)
+// @param.define requestID X-Request-ID header string false "Request identifier, echoed in the response"
+// @response.define InternalError {object} web.APIError "Internal server error"
+// @response.define NotFound {object} web.APIError "Resource not found"
+// @response.define NoContent "No content"
+func main() {
+	http.HandleFunc("/api/v1/pets", api.ListPets)
+	http.HandleFunc("/api/v1/pets/{id}", api.DeletePet)
+	http.ListenAndServe(":8080", nil)
+}
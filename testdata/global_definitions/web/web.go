@@ -0,0 +1,13 @@
+package web
+
+// Pet is a pet of the store.
+type Pet struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// APIError is the body of every failed response.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
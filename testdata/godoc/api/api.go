@@ -0,0 +1,44 @@
+package api
+
+import "net/http"
+
+// User is a user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// ListUsers lists the users of the API. They are sorted by name,
+// the active ones first.
+//
+// The deleted users are not listed.
+//
+//	@Tags		users
+//	@Success	200	{array}	User
+//	@Router		/users [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+// GetUser godoc
+//
+// GetUser returns a user of the API, by its ID.
+//
+//	@Summary	Get a user
+//	@Tags		users
+//	@Param		id	path		int	true	"The ID of the user"
+//	@Success	200	{object}	User
+//	@Router		/users/{id} [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser deletes a user of the API.
+//
+//	@Summary		Delete a user
+//	@Description	The user is marked as deleted.
+//	@Tags			users
+//	@Param			id	path	int	true	"The ID of the user"
+//	@Success		204
+//	@Router			/users/{id} [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
+
+//	@Success	200
+//	@Router		/ping [get]
+func Ping(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,10 @@
+package admin
+
+import "net/http"
+
+// ListAudit lists the audit log.
+//
+// @Summary List the audit log
+// @Success 200 {string} string
+// @Router /admin/audit [get]
+func ListAudit(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,4 @@
+// Package admin holds the administration routes.
+//
+// @Tags admin
+package admin
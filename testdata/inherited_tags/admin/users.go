@@ -0,0 +1,18 @@
+// @Tags users
+package admin
+
+import "net/http"
+
+// ListUsers lists the users.
+//
+// @Summary List the users
+// @Success 200 {string} string
+// @Router /admin/users [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser deletes a user.
+//
+// @Summary Delete a user
+// @Success 204
+// @Router /admin/users/{id} [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,19 @@
+package api
+
+import "net/http"
+
+// ListOrders lists the orders.
+//
+// @Summary List the orders
+// @Use audited
+// @Success 200 {string} string
+// @Router /orders [get]
+func ListOrders(w http.ResponseWriter, r *http.Request) {}
+
+// GetOrder returns an order.
+//
+// @Summary Get an order
+// @Tags orders
+// @Success 200 {string} string
+// @Router /orders/{id} [get]
+func GetOrder(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/inherited_tags/admin"
+	"github.com/swaggo/swag/testdata/inherited_tags/api"
+)
+
+// @title Swagger Inherited Tags API
+// @version 1.0
+// @BasePath /api
+func main() {
+	http.HandleFunc("/api/orders", api.ListOrders)
+	http.HandleFunc("/api/admin/users", admin.ListUsers)
+	http.ListenAndServe(":8080", nil)
+}
+
+// @Template audited
+// @Tags audit
+// @Failure 403 {string} string "Forbidden"
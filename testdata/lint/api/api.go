@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/lint/model"
+)
+
+var _ model.User
+
+// GetUser follows every rule.
+//
+// @Summary Get a user
+// @ID getUser
+// @Tags users
+// @Param id path int true "User ID"
+// @Success 200 {object} model.User
+// @Router /users/{id} [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// UpdateUser breaks most rules.
+//
+// @Tags users,admin
+// @Param name path string true "User name"
+// @Sucess 200 {object} model.Group
+// @Failure 204 {string} string "No content"
+// @Router /users/{id} [put]
+func UpdateUser(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser suppresses some rules.
+//
+//swag:nolint operation-id,undeclared-tag
+// @Summary Delete a user
+// @Tags admin
+// @Param id path int true "User ID"
+// @Success 204 {object} model.Group
+// @Router /users/{id} [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/lint/api"
+)
+
+// @title Swagger Lint API
+// @version 1.0
+// @description Operations breaking and following the lint rules.
+// @BasePath /api
+
+// @tag.name users
+// @tag.description Users of the API
+func main() {
+	http.HandleFunc("/api/users/{id}", api.GetUser)
+	http.ListenAndServe(":8080", nil)
+}
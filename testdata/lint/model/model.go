@@ -0,0 +1,19 @@
+package model
+
+// User is a user of the API.
+// @Description A user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// Role is the role of a user.
+type Role struct {
+	Name string `json:"name"`
+}
+
+//swag:nolint
+type Group struct {
+	Name string `json:"name"`
+}
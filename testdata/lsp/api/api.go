@@ -0,0 +1,26 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/lsp/model"
+)
+
+var _ model.User
+
+// GetUser gets a user.
+//
+// @Summary Get a user
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} model.User
+// @Router /users/{id} [get]
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser deletes a user.
+//
+// @Summary Delete a user
+// @Param id path int true "User ID"
+// @Success 204 {object} model.Unknown
+// @Router /users/{id} [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/lsp/api"
+)
+
+// @title Swagger LSP API
+// @version 1.0
+// @BasePath /api
+func main() {
+	http.HandleFunc("/api/users/{id}", api.GetUser)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,7 @@
+package model
+
+// User is a user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
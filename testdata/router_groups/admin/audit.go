@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/router_groups/web"
+)
+
+var _ web.AuditEntry
+
+// ListAudit lists the audit log, readable with a dedicated key.
+//
+// @Summary List the audit log
+// @Security AuditKeyAuth
+// @Produce json,plain
+// @Success 200 {array} web.AuditEntry
+// @Router /audit [get]
+func ListAudit(w http.ResponseWriter, r *http.Request) {}
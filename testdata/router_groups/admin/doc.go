@@ -0,0 +1,8 @@
+// Package admin holds the administration routes.
+//
+// @BasePath /admin
+// @Tags admin
+// @Security ApiKeyAuth
+// @Produce json
+// @Failure 401 {object} web.APIError "Unauthorized"
+package admin
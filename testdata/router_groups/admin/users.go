@@ -0,0 +1,28 @@
+// @BasePath /users
+// @Tags users
+// @Header all {string} X-Request-ID "Request identifier"
+package admin
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/router_groups/web"
+)
+
+var _ web.User
+
+// ListUsers lists the users.
+//
+// @Summary List users
+// @Success 200 {array} web.User
+// @Router / [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+// DeleteUser deletes a user.
+//
+// @Summary Delete a user
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 401 {string} string "Token expired"
+// @Router /{id} [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
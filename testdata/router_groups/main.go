@@ -0,0 +1,31 @@
+// Package main serves an API whose admin routes are grouped by package.
+//
+// @title Swagger Router Groups API
+// @version 1.0
+// @description Operations inheriting the annotations of their package and file.
+// @BasePath /v2
+//
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/router_groups/admin"
+)
+
+// @Summary Health check
+// @Produce plain
+// @Success 200 {string} string "ok"
+// @Router /health [get]
+func health(w http.ResponseWriter, r *http.Request) {}
+
+func main() {
+	http.HandleFunc("/v2/health", health)
+	http.HandleFunc("/v2/admin/users", admin.ListUsers)
+	http.HandleFunc("/v2/admin/users/{id}", admin.DeleteUser)
+	http.HandleFunc("/v2/admin/audit", admin.ListAudit)
+	http.ListenAndServe(":8080", nil)
+}
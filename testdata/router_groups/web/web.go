@@ -0,0 +1,19 @@
+package web
+
+// User is a user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AuditEntry is an entry of the audit log.
+type AuditEntry struct {
+	User   string `json:"user"`
+	Action string `json:"action"`
+}
+
+// APIError is the body of every failed response.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
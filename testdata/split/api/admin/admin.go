@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"net/http"
+
+	_ "github.com/swaggo/swag/testdata/split/model"
+)
+
+// DeleteUser godoc
+// @Summary Delete a user
+// @Tags admin,users
+// @Param id query int true "User ID"
+// @Success 204
+// @Failure 500 {object} model.Error
+// @Router /admin/users [delete]
+func DeleteUser(w http.ResponseWriter, r *http.Request) {}
+
+// Audit godoc
+// @Summary List the audit entries
+// @Tags admin
+// @Produce json
+// @Success 200 {array} model.AuditEntry
+// @Router /admin/audit [get]
+func Audit(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,23 @@
+package public
+
+import (
+	"net/http"
+
+	_ "github.com/swaggo/swag/testdata/split/model"
+)
+
+// ListUsers godoc
+// @Summary List the users
+// @Tags users
+// @Produce json
+// @Success 200 {object} model.UserPage
+// @Failure 500 {object} model.Error
+// @Router /v1/users [get]
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+// Health godoc
+// @Summary Check the health of the service
+// @Tags internal
+// @Success 204
+// @Router /v1/health [get]
+func Health(w http.ResponseWriter, r *http.Request) {}
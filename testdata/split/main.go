@@ -0,0 +1,27 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/split/api/admin"
+	"github.com/swaggo/swag/testdata/split/api/public"
+)
+
+// @title Swagger Split API
+// @version 1.0
+// @description One codebase documented as a public and an internal API.
+// @BasePath /
+
+// @tag.name users
+// @tag.description Public user operations
+// @tag.name admin
+// @tag.description Administration
+// @tag.name internal
+// @tag.description Internal operations
+func main() {
+	http.HandleFunc("/v1/users", public.ListUsers)
+	http.HandleFunc("/v1/health", public.Health)
+	http.HandleFunc("/admin/users", admin.DeleteUser)
+	http.HandleFunc("/admin/audit", admin.Audit)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,30 @@
+package model
+
+// User is a user of the API.
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// UserPage is a page of users.
+type UserPage struct {
+	Items []User `json:"items"`
+	Next  string `json:"next"`
+}
+
+// AuditEntry records an administration operation.
+type AuditEntry struct {
+	Author User   `json:"author"`
+	Action string `json:"action"`
+}
+
+// Error is returned by every operation which fails.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// AuditFilter filters the audit entries.
+// @Description Filter of the audit entries, which no operation exposes yet.
+type AuditFilter struct {
+	Action string `json:"action"`
+}
@@ -0,0 +1,10 @@
+package health
+
+import "net/http"
+
+// Check reports the health of the API.
+//
+// @Summary Check the health
+// @Success 200 {string} string
+// @Router /health [get]
+func Check(w http.ResponseWriter, r *http.Request) {}
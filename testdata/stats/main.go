@@ -0,0 +1,35 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/stats/health"
+	"github.com/swaggo/swag/testdata/stats/orders"
+	"github.com/swaggo/swag/testdata/stats/router"
+	"github.com/swaggo/swag/testdata/stats/users"
+)
+
+// @title Swagger Stats API
+// @version 1.0
+// @BasePath /api
+func main() {
+	r := router.New()
+	h := users.Handler{}
+
+	api := r.Group("/api")
+	{
+		api.GET("/users/:id", users.GetUser)
+		api.POST("/users", users.CreateUser)
+		api.PUT("/users/:id", h.UpdateUser)
+		api.DELETE("/users/:id", router.Logger(), users.DeleteUser)
+	}
+
+	admin := api.Group("/admin")
+	admin.GET("/stats", func(c *router.Context) {})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/health", health.Check)
+	mux.HandleFunc("/api/orders/{id}", orders.Handler)
+	mux.Handle("/", r)
+	http.ListenAndServe(":8080", mux)
+}
@@ -0,0 +1,6 @@
+package orders
+
+import "net/http"
+
+// Handler serves the orders.
+func Handler(w http.ResponseWriter, r *http.Request) {}
@@ -0,0 +1,25 @@
+package router
+
+import "net/http"
+
+type Context struct{}
+
+type HandlerFunc func(*Context)
+
+type Group struct{}
+
+func New() *Group { return &Group{} }
+
+func (g *Group) Group(prefix string) *Group { return g }
+
+func (g *Group) GET(path string, handlers ...HandlerFunc) {}
+
+func (g *Group) POST(path string, handlers ...HandlerFunc) {}
+
+func (g *Group) PUT(path string, handlers ...HandlerFunc) {}
+
+func (g *Group) DELETE(path string, handlers ...HandlerFunc) {}
+
+func (g *Group) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+func Logger() HandlerFunc { return func(*Context) {} }
@@ -0,0 +1,39 @@
+package users
+
+import "github.com/swaggo/swag/testdata/stats/router"
+
+// GetUser gets a user.
+//
+// @Summary Get a user
+// @Param id path int true "User ID"
+// @Success 200 {string} string
+// @Router /users/{id} [get]
+func GetUser(c *router.Context) {}
+
+// ListUsers lists the users.
+//
+// @Summary List the users
+// @Success 200 {string} string
+// @Router /users [get]
+func ListUsers(c *router.Context) {}
+
+// CreateUser creates a user.
+func CreateUser(c *router.Context) {}
+
+// DeleteUser deletes a user.
+//
+// @Summary Delete a user
+// @Param id path int true "User ID"
+// @Success 204
+// @Router /users/{id} [delete]
+func DeleteUser(c *router.Context) {}
+
+type Handler struct{}
+
+// UpdateUser updates a user.
+//
+// @Summary Update a user
+// @Param id path int true "User ID"
+// @Success 200 {string} string
+// @Router /users/{id} [put]
+func (Handler) UpdateUser(c *router.Context) {}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	_ "github.com/swaggo/swag/testdata/templates/web"
+)
+
+// paginated is never routed, it only declares a template.
+//
+// @Template paginated
+// @Use authed
+// @Param page query int false "Page number"
+// @Param size query int false "Page size"
+// @Header 200 {integer} X-Total-Count "Total number of items"
+func paginated() {}
+
+// ListOrders godoc
+// @Summary List the orders
+// @Tags orders
+// @Use paginated
+// @Success 200 {array} web.Order
+// @Router /orders [get]
+func ListOrders(w http.ResponseWriter, r *http.Request) {}
+
+// GetOrder godoc
+// @Summary Get an order
+// @Tags orders
+// @Use authed
+// @Produce xml
+// @Param id path int true "Order ID"
+// @Success 200 {object} web.Order
+// @Failure 500 {string} string "Storage unavailable"
+// @Router /orders/{id} [get]
+func GetOrder(w http.ResponseWriter, r *http.Request) {}
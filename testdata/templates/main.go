@@ -0,0 +1,29 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/templates/api"
+)
+
+// @title Swagger Templates API
+// @version 1.0
+// @description Operations sharing annotation blocks through templates.
+// @BasePath /api/v1
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+func main() {
+	http.HandleFunc("/api/v1/orders", api.ListOrders)
+	http.HandleFunc("/api/v1/orders/{id}", api.GetOrder)
+	http.ListenAndServe(":8080", nil)
+}
+
+// @Template authed
+// @Security ApiKeyAuth
+// @Produce json
+// @Param X-Request-ID header string false "Request identifier"
+// @Failure 401 {object} web.APIError "Unauthorized"
+// @Failure 403 {object} web.APIError "Forbidden"
+// @Failure 500 {object} web.APIError "Internal server error"
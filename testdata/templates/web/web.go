@@ -0,0 +1,13 @@
+package web
+
+// Order is an order of the store.
+type Order struct {
+	ID    int     `json:"id"`
+	Total float64 `json:"total"`
+}
+
+// APIError is the body of every failed response.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
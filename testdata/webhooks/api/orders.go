@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/webhooks/model"
+)
+
+// CreateOrder creates an order, and calls back the URL of the request once it is paid.
+//
+// @Summary Create an order
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param order body model.OrderRequest true "Order"
+// @Success 201 {object} model.Order
+// @Callback onPaid {$request.body#/callbackUrl} orderPaid
+// @Router /orders [post]
+func CreateOrder(w http.ResponseWriter, r *http.Request) {}
+
+// notifyOrderPaid sends the paid order to the subscribers.
+//
+// @Summary An order was paid
+// @Tags orders
+// @Accept json
+// @Param event body model.OrderPaidEvent true "Event"
+// @Param X-Signature header string true "HMAC of the body"
+// @Success 200 "Event received"
+// @Failure 410 "Unsubscribe"
+// @Webhook orderPaid [post]
+func notifyOrderPaid(order model.Order) {}
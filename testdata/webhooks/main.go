@@ -0,0 +1,16 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/swaggo/swag/testdata/webhooks/api"
+)
+
+// @title Swagger Webhooks API
+// @version 1.0
+// @description Operations calling back their clients and outbound webhooks.
+// @BasePath /api
+func main() {
+	http.HandleFunc("/api/orders", api.CreateOrder)
+	http.ListenAndServe(":8080", nil)
+}
@@ -0,0 +1,19 @@
+package model
+
+// OrderRequest is the body of a new order.
+type OrderRequest struct {
+	Items       []string `json:"items"`
+	CallbackURL string   `json:"callbackUrl"`
+}
+
+// Order is an order of the store.
+type Order struct {
+	ID    int      `json:"id"`
+	Items []string `json:"items"`
+}
+
+// OrderPaidEvent is sent when an order was paid.
+type OrderPaidEvent struct {
+	Order  Order   `json:"order"`
+	Amount float64 `json:"amount"`
+}
@@ -32,6 +32,11 @@ type TypeSpecDef struct {
 	SchemaName string
 
 	NotUnique bool
+
+	// origin is the generic type of an instantiated one, instantiated with typeArgs, and
+	// typeArgs the slice or map of a type argument when there is no origin
+	origin   *TypeSpecDef
+	typeArgs []string
 }
 
 // Name the name of the typeSpec.
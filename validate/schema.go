@@ -0,0 +1,349 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/spec"
+)
+
+// schemaValidator validates decoded values against the schemas of a swagger document.
+type schemaValidator struct {
+	swagger  *spec.Swagger
+	patterns sync.Map
+}
+
+func newSchemaValidator(swagger *spec.Swagger) *schemaValidator {
+	return &schemaValidator{swagger: swagger}
+}
+
+// validateValue adds an error to result for every constraint of schema value violates.
+// name is the location of value, reported in the errors.
+func (s *schemaValidator) validateValue(result *Error, in, name string, value any, schema *spec.Schema) {
+	schema = s.resolve(schema)
+	if schema == nil {
+		return
+	}
+
+	for i := range schema.AllOf {
+		s.validateValue(result, in, name, value, &schema.AllOf[i])
+	}
+
+	if value == nil {
+		if len(schema.Type) > 0 && !schema.Type.Contains("null") && !isNullable(schema) {
+			result.add(in, name, "must not be null")
+		}
+
+		return
+	}
+
+	if len(schema.Type) > 0 && !matchesType(value, schema.Type) {
+		result.add(in, name, "must be of type %s", strings.Join(schema.Type, " or "))
+
+		return
+	}
+
+	if len(schema.Enum) > 0 && !containsValue(schema.Enum, value) {
+		result.add(in, name, "must be one of %s", enumText(schema.Enum))
+	}
+
+	switch value := value.(type) {
+	case string:
+		s.validateString(result, in, name, value, schema)
+	case json.Number, float64, int64:
+		if number, ok := toFloat(value); ok {
+			validateNumber(result, in, name, number, schema)
+		}
+	case []any:
+		s.validateArray(result, in, name, value, schema)
+	case map[string]any:
+		s.validateObject(result, in, name, value, schema)
+	}
+}
+
+func (s *schemaValidator) validateString(result *Error, in, name, value string, schema *spec.Schema) {
+	length := int64(len([]rune(value)))
+
+	if schema.MinLength != nil && length < *schema.MinLength {
+		result.add(in, name, "must be at least %d characters long", *schema.MinLength)
+	}
+
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		result.add(in, name, "must be at most %d characters long", *schema.MaxLength)
+	}
+
+	if schema.Pattern != "" {
+		pattern, err := s.pattern(schema.Pattern)
+		if err != nil {
+			result.add(in, name, "has invalid pattern %q in the documentation", schema.Pattern)
+		} else if !pattern.MatchString(value) {
+			result.add(in, name, "must match pattern %q", schema.Pattern)
+		}
+	}
+}
+
+func validateNumber(result *Error, in, name string, value float64, schema *spec.Schema) {
+	if schema.Minimum != nil {
+		if schema.ExclusiveMinimum && value <= *schema.Minimum {
+			result.add(in, name, "must be greater than %v", *schema.Minimum)
+		} else if value < *schema.Minimum {
+			result.add(in, name, "must be greater than or equal to %v", *schema.Minimum)
+		}
+	}
+
+	if schema.Maximum != nil {
+		if schema.ExclusiveMaximum && value >= *schema.Maximum {
+			result.add(in, name, "must be less than %v", *schema.Maximum)
+		} else if value > *schema.Maximum {
+			result.add(in, name, "must be less than or equal to %v", *schema.Maximum)
+		}
+	}
+
+	if schema.MultipleOf != nil && *schema.MultipleOf != 0 {
+		if quotient := value / *schema.MultipleOf; quotient != math.Trunc(quotient) {
+			result.add(in, name, "must be a multiple of %v", *schema.MultipleOf)
+		}
+	}
+}
+
+func (s *schemaValidator) validateArray(result *Error, in, name string, value []any, schema *spec.Schema) {
+	length := int64(len(value))
+
+	if schema.MinItems != nil && length < *schema.MinItems {
+		result.add(in, name, "must contain at least %d items", *schema.MinItems)
+	}
+
+	if schema.MaxItems != nil && length > *schema.MaxItems {
+		result.add(in, name, "must contain at most %d items", *schema.MaxItems)
+	}
+
+	if schema.UniqueItems {
+		for i := 1; i < len(value); i++ {
+			if containsValue(value[:i], value[i]) {
+				result.add(in, name, "must contain unique items")
+
+				break
+			}
+		}
+	}
+
+	if schema.Items == nil {
+		return
+	}
+
+	for i, item := range value {
+		itemSchema := schema.Items.Schema
+		if itemSchema == nil {
+			if i >= len(schema.Items.Schemas) {
+				continue
+			}
+
+			itemSchema = &schema.Items.Schemas[i]
+		}
+
+		s.validateValue(result, in, fmt.Sprintf("%s[%d]", name, i), item, itemSchema)
+	}
+}
+
+func (s *schemaValidator) validateObject(result *Error, in, name string, value map[string]any, schema *spec.Schema) {
+	for _, required := range schema.Required {
+		if _, ok := value[required]; !ok {
+			result.add(in, name+"."+required, "is required")
+		}
+	}
+
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if property, ok := schema.Properties[key]; ok {
+			if property.ReadOnly {
+				continue
+			}
+
+			s.validateValue(result, in, name+"."+key, value[key], &property)
+
+			continue
+		}
+
+		if schema.AdditionalProperties == nil {
+			continue
+		}
+
+		if schema.AdditionalProperties.Schema != nil {
+			s.validateValue(result, in, name+"."+key, value[key], schema.AdditionalProperties.Schema)
+		} else if !schema.AdditionalProperties.Allows {
+			result.add(in, name+"."+key, "is not allowed")
+		}
+	}
+}
+
+// resolve follows the definition references of schema.
+func (s *schemaValidator) resolve(schema *spec.Schema) *spec.Schema {
+	for depth := 0; schema != nil && depth < 32; depth++ {
+		ref := schema.Ref.String()
+		if ref == "" {
+			return schema
+		}
+
+		definition, ok := s.swagger.Definitions[strings.TrimPrefix(ref, "#/definitions/")]
+		if !ok || !strings.HasPrefix(ref, "#/definitions/") {
+			return nil
+		}
+
+		schema = &definition
+	}
+
+	return schema
+}
+
+func (s *schemaValidator) pattern(expr string) (*regexp.Regexp, error) {
+	if pattern, ok := s.patterns.Load(expr); ok {
+		return pattern.(*regexp.Regexp), nil
+	}
+
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	s.patterns.Store(expr, pattern)
+
+	return pattern, nil
+}
+
+// parseValue converts a parameter value to the type it is declared with.
+func parseValue(value, paramType, format string) (any, error) {
+	switch paramType {
+	case "integer":
+		number, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errors.New("must be an integer")
+		}
+
+		if format == "int32" && (number < math.MinInt32 || number > math.MaxInt32) {
+			return nil, errors.New("must be a 32 bit integer")
+		}
+
+		return number, nil
+	case "number":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("must be a number")
+		}
+
+		return number, nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.New("must be a boolean")
+		}
+
+		return b, nil
+	}
+
+	return value, nil
+}
+
+func matchesType(value any, types spec.StringOrArray) bool {
+	for _, schemaType := range types {
+		switch schemaType {
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := toFloat(value); ok {
+				return true
+			}
+		case "integer":
+			if number, ok := toFloat(value); ok && number == math.Trunc(number) {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]any); ok {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]any); ok {
+				return true
+			}
+		case "file":
+			return true
+		}
+	}
+
+	return false
+}
+
+func toFloat(value any) (float64, bool) {
+	switch value := value.(type) {
+	case json.Number:
+		number, err := value.Float64()
+
+		return number, err == nil
+	case float64:
+		return value, true
+	case int64:
+		return float64(value), true
+	}
+
+	return 0, false
+}
+
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if equalValues(candidate, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalValues compares values, treating numbers of different representations as equal.
+func equalValues(a, b any) bool {
+	if x, ok := toFloat(a); ok {
+		y, ok := toFloat(b)
+
+		return ok && x == y
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func enumText(enum []any) string {
+	values := make([]string, 0, len(enum))
+	for _, value := range enum {
+		b, _ := json.Marshal(value)
+		values = append(values, string(b))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func isNullable(schema *spec.Schema) bool {
+	if schema.Nullable {
+		return true
+	}
+
+	nullable, _ := schema.Extensions["x-nullable"].(bool)
+
+	return nullable
+}
@@ -0,0 +1,526 @@
+// Package validate provides net/http middleware validating requests against the
+// operations of a registered swag document.
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-openapi/spec"
+	"github.com/swaggo/swag"
+)
+
+// FieldError describes a single value failing validation.
+type FieldError struct {
+	// In is the location of the value: path, query, header, formData or body.
+	In string `json:"in"`
+	// Name is the parameter name, or the location of the value inside the body, e.g. body.tags[0].name.
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// Error is returned for a request failing validation, and written as the body of the
+// 400 response by the middleware.
+type Error struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s %s: %s", fieldError.In, fieldError.Name, fieldError.Message))
+	}
+
+	return e.Message + ": " + strings.Join(messages, "; ")
+}
+
+func (e *Error) add(in, name, format string, args ...any) {
+	e.Errors = append(e.Errors, FieldError{In: in, Name: name, Message: fmt.Sprintf(format, args...)})
+}
+
+// ErrorHandler writes the response for a request failing validation.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err *Error)
+
+// DefaultMaxBodySize is the size of the largest request body read by default, the one
+// net/http reads from url encoded forms.
+const DefaultMaxBodySize = 10 << 20
+
+// Validator validates requests against the operations of a swagger document.
+type Validator struct {
+	swagger      *spec.Swagger
+	basePath     string
+	routes       []route
+	errorHandler ErrorHandler
+	schemas      *schemaValidator
+	maxBodySize  int64
+}
+
+// route is a path of the document split into segments, with {param} segments kept as is.
+type route struct {
+	segments []string
+	item     *spec.PathItem
+}
+
+// WithErrorHandler sets the handler writing the response for invalid requests.
+func WithErrorHandler(handler ErrorHandler) func(*Validator) {
+	return func(v *Validator) {
+		v.errorHandler = handler
+	}
+}
+
+// WithMaxBodySize sets the size of the largest request body read to validate it, in bytes.
+// Requests with larger bodies are invalid.
+func WithMaxBodySize(size int64) func(*Validator) {
+	return func(v *Validator) {
+		v.maxBodySize = size
+	}
+}
+
+// New creates a Validator for the swag instance registered under name.
+func New(name string, options ...func(*Validator)) (*Validator, error) {
+	if instance, ok := swag.GetSwagger(name).(*swag.Spec); ok {
+		swagger, err := instance.Document()
+		if err != nil {
+			return nil, err
+		}
+
+		return NewWithSwagger(swagger, options...), nil
+	}
+
+	doc, err := swag.ReadDoc(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var swagger spec.Swagger
+	if err = json.Unmarshal([]byte(doc), &swagger); err != nil {
+		return nil, fmt.Errorf("cannot parse swag document %q: %w", name, err)
+	}
+
+	return NewWithSwagger(&swagger, options...), nil
+}
+
+// NewWithSwagger creates a Validator for swagger.
+func NewWithSwagger(swagger *spec.Swagger, options ...func(*Validator)) *Validator {
+	v := &Validator{
+		swagger:      swagger,
+		basePath:     strings.TrimSuffix(swagger.BasePath, "/"),
+		errorHandler: writeError,
+		schemas:      newSchemaValidator(swagger),
+		maxBodySize:  DefaultMaxBodySize,
+	}
+
+	if swagger.Paths != nil {
+		paths := make([]string, 0, len(swagger.Paths.Paths))
+		for path := range swagger.Paths.Paths {
+			paths = append(paths, path)
+		}
+
+		sort.Strings(paths)
+
+		for _, path := range paths {
+			item := swagger.Paths.Paths[path]
+			v.routes = append(v.routes, route{segments: splitPath(path), item: &item})
+		}
+	}
+
+	for _, option := range options {
+		option(v)
+	}
+
+	return v
+}
+
+// Middleware returns net/http middleware validating requests against the swag instance
+// registered under name. The document is read on the first request, and on the next
+// ones until it can be read, so the middleware can be created before the docs package
+// registers it.
+func Middleware(name string, options ...func(*Validator)) func(http.Handler) http.Handler {
+	var (
+		mu        sync.Mutex
+		validator *Validator
+	)
+
+	load := func() (*Validator, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if validator == nil {
+			v, err := New(name, options...)
+			if err != nil {
+				return nil, err
+			}
+
+			validator = v
+		}
+
+		return validator, nil
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v, err := load()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+
+				return
+			}
+
+			v.Handler(next).ServeHTTP(w, r)
+		})
+	}
+}
+
+// Handler validates requests before passing them to next. Requests which do not match
+// a documented operation are passed through unchecked.
+func (v *Validator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.validate(r); err != nil {
+			v.errorHandler(w, r, err)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Validate validates r against the operation it matches. It returns an *Error if the
+// request is invalid. The request body is restored after being read.
+func (v *Validator) Validate(r *http.Request) error {
+	if err := v.validate(r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *Validator) validate(r *http.Request) *Error {
+	operation, pathItem, pathParams := v.match(r)
+	if operation == nil {
+		return nil
+	}
+
+	result := &Error{Message: "request validation failed"}
+
+	var (
+		query    = r.URL.Query()
+		form     url.Values
+		formRead bool
+		body     *spec.Parameter
+	)
+
+	for _, param := range v.parameters(pathItem, operation) {
+		switch param.In {
+		case "path":
+			value, ok := pathParams[param.Name]
+			v.validateParameter(result, param, ok, []string{value})
+		case "query":
+			values, ok := query[param.Name]
+			v.validateParameter(result, param, ok, values)
+		case "header":
+			values, ok := r.Header[http.CanonicalHeaderKey(param.Name)]
+			v.validateParameter(result, param, ok, values)
+		case "formData":
+			if !formRead {
+				formRead = true
+
+				var err error
+				if form, err = v.readForm(r); err != nil {
+					result.add("formData", param.Name, "%s", err)
+				}
+			}
+
+			if form != nil {
+				values, ok := form[param.Name]
+				v.validateParameter(result, param, ok, values)
+			}
+		case "body":
+			body = param
+		}
+	}
+
+	if body != nil {
+		v.validateBody(result, r, body)
+	}
+
+	if len(result.Errors) == 0 {
+		return nil
+	}
+
+	return result
+}
+
+// match finds the operation for r, preferring paths with the most literal segments.
+func (v *Validator) match(r *http.Request) (*spec.Operation, *spec.PathItem, map[string]string) {
+	path := r.URL.EscapedPath()
+	if v.basePath != "" {
+		if path != v.basePath && !strings.HasPrefix(path, v.basePath+"/") {
+			return nil, nil, nil
+		}
+
+		path = path[len(v.basePath):]
+	}
+
+	segments := splitPath(path)
+
+	var (
+		best       *route
+		bestParams map[string]string
+		bestScore  = -1
+	)
+
+	for i := range v.routes {
+		params, score, ok := v.routes[i].match(segments)
+		if ok && score > bestScore {
+			best, bestParams, bestScore = &v.routes[i], params, score
+		}
+	}
+
+	if best == nil {
+		return nil, nil, nil
+	}
+
+	operation := swag.PathItemOperation(best.item, r.Method)
+	if operation == nil {
+		return nil, nil, nil
+	}
+
+	return operation, best.item, bestParams
+}
+
+// match reports whether segments match the route, and the number of literal segments.
+func (rt *route) match(segments []string) (map[string]string, int, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, 0, false
+	}
+
+	params := make(map[string]string)
+	score := 0
+
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return nil, 0, false
+			}
+
+			params[segment[1:len(segment)-1]] = value
+
+			continue
+		}
+
+		if segment != segments[i] {
+			return nil, 0, false
+		}
+
+		score++
+	}
+
+	return params, score, true
+}
+
+// parameters returns the parameters of operation, including the ones declared on the path
+// which are not overridden by the operation.
+func (v *Validator) parameters(pathItem *spec.PathItem, operation *spec.Operation) []*spec.Parameter {
+	var params []*spec.Parameter
+
+	seen := make(map[string]bool)
+
+	for _, list := range [][]spec.Parameter{operation.Parameters, pathItem.Parameters} {
+		for i := range list {
+			param := v.resolveParameter(&list[i])
+			if key := param.In + "." + param.Name; !seen[key] {
+				seen[key] = true
+				params = append(params, param)
+			}
+		}
+	}
+
+	return params
+}
+
+func (v *Validator) resolveParameter(param *spec.Parameter) *spec.Parameter {
+	ref := param.Ref.String()
+	if !strings.HasPrefix(ref, "#/parameters/") {
+		return param
+	}
+
+	if resolved, ok := v.swagger.Parameters[strings.TrimPrefix(ref, "#/parameters/")]; ok {
+		return &resolved
+	}
+
+	return param
+}
+
+func (v *Validator) validateParameter(result *Error, param *spec.Parameter, present bool, values []string) {
+	if !present || len(values) == 0 || (len(values) == 1 && values[0] == "" && param.Type != "string") {
+		if param.Required {
+			result.add(param.In, param.Name, "is required")
+		}
+
+		return
+	}
+
+	if param.Type != "array" {
+		value, err := parseValue(values[0], param.Type, param.Format)
+		if err != nil {
+			result.add(param.In, param.Name, "%s", err)
+
+			return
+		}
+
+		v.schemas.validateValue(result, param.In, param.Name, value, simpleSchema(&param.SimpleSchema, &param.CommonValidations))
+
+		return
+	}
+
+	if param.CollectionFormat != "multi" {
+		values = splitCollection(values[0], param.CollectionFormat)
+	}
+
+	items := make([]any, 0, len(values))
+
+	for i, raw := range values {
+		if param.Items == nil {
+			items = append(items, raw)
+
+			continue
+		}
+
+		value, err := parseValue(raw, param.Items.Type, param.Items.Format)
+		if err != nil {
+			result.add(param.In, fmt.Sprintf("%s[%d]", param.Name, i), "%s", err)
+
+			return
+		}
+
+		items = append(items, value)
+	}
+
+	schema := simpleSchema(&param.SimpleSchema, &param.CommonValidations)
+	if param.Items != nil {
+		schema.Items = &spec.SchemaOrArray{Schema: simpleSchema(&param.Items.SimpleSchema, &param.Items.CommonValidations)}
+	}
+
+	v.schemas.validateValue(result, param.In, param.Name, items, schema)
+}
+
+func (v *Validator) validateBody(result *Error, r *http.Request, param *spec.Parameter) {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil &&
+		mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return
+	}
+
+	b, err := v.readBody(r)
+	if err != nil {
+		result.add("body", param.Name, "%s", err)
+
+		return
+	}
+
+	if len(bytes.TrimSpace(b)) == 0 {
+		if param.Required {
+			result.add("body", param.Name, "is required")
+		}
+
+		return
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		result.add("body", param.Name, "invalid JSON: %s", err)
+
+		return
+	}
+
+	if param.Schema != nil {
+		v.schemas.validateValue(result, "body", "body", value, param.Schema)
+	}
+}
+
+// readBody reads the body of r and replaces it, so that it can be read again. Bodies
+// larger than the maximum size are not read further.
+func (v *Validator) readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+
+	b, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, v.maxBodySize))
+	_ = r.Body.Close()
+
+	r.Body = io.NopCloser(bytes.NewReader(b))
+
+	return b, err
+}
+
+// readForm parses the url encoded form of r without consuming its body. Other forms,
+// such as multipart ones, are not validated.
+func (v *Validator) readForm(r *http.Request) (url.Values, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-www-form-urlencoded" {
+		return nil, nil
+	}
+
+	b, err := v.readBody(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return url.ParseQuery(string(b))
+}
+
+func writeError(w http.ResponseWriter, _ *http.Request, err *Error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusBadRequest)
+
+	_ = json.NewEncoder(w).Encode(err)
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func splitCollection(value, collectionFormat string) []string {
+	switch collectionFormat {
+	case "ssv":
+		return strings.Split(value, " ")
+	case "tsv":
+		return strings.Split(value, "\t")
+	case "pipes":
+		return strings.Split(value, "|")
+	}
+
+	return strings.Split(value, ",")
+}
+
+func simpleSchema(simple *spec.SimpleSchema, validations *spec.CommonValidations) *spec.Schema {
+	return &spec.Schema{SchemaProps: spec.SchemaProps{
+		Format:           simple.Format,
+		Enum:             validations.Enum,
+		Maximum:          validations.Maximum,
+		ExclusiveMaximum: validations.ExclusiveMaximum,
+		Minimum:          validations.Minimum,
+		ExclusiveMinimum: validations.ExclusiveMinimum,
+		MaxLength:        validations.MaxLength,
+		MinLength:        validations.MinLength,
+		Pattern:          validations.Pattern,
+		MaxItems:         validations.MaxItems,
+		MinItems:         validations.MinItems,
+		UniqueItems:      validations.UniqueItems,
+		MultipleOf:       validations.MultipleOf,
+	}}
+}
@@ -0,0 +1,249 @@
+package validate
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/swaggo/swag"
+)
+
+const petsDoc = `{
+    "swagger": "2.0",
+    "info": {"title": "Pets", "version": "1.0"},
+    "basePath": "/api/v1",
+    "paths": {
+        "/pets": {
+            "get": {
+                "parameters": [
+                    {"type": "integer", "name": "limit", "in": "query", "required": true, "minimum": 1, "maximum": 100},
+                    {"enum": ["asc", "desc"], "type": "string", "name": "order", "in": "query"},
+                    {"type": "array", "items": {"type": "integer"}, "collectionFormat": "csv", "name": "ids", "in": "query"},
+                    {"type": "string", "name": "X-Request-Id", "in": "header", "required": true, "pattern": "^[a-f0-9]+$"}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            },
+            "post": {
+                "parameters": [
+                    {"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/model.Pet"}}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            }
+        },
+        "/pets/{id}": {
+            "get": {
+                "parameters": [
+                    {"type": "integer", "name": "id", "in": "path", "required": true}
+                ],
+                "responses": {"200": {"description": "OK"}}
+            }
+        },
+        "/pets/mine": {
+            "get": {
+                "responses": {"200": {"description": "OK"}}
+            }
+        }
+    },
+    "definitions": {
+        "model.Pet": {
+            "type": "object",
+            "required": ["name"],
+            "properties": {
+                "id": {"type": "integer", "readOnly": true},
+                "name": {"type": "string", "minLength": 1},
+                "status": {"type": "string", "enum": ["available", "sold"]},
+                "tags": {"type": "array", "items": {"$ref": "#/definitions/model.Tag"}}
+            }
+        },
+        "model.Tag": {
+            "type": "object",
+            "properties": {
+                "name": {"type": "string"}
+            }
+        }
+    }
+}`
+
+func init() {
+	swag.Register("validate_test", &swag.Spec{InfoInstanceName: "validate_test", SwaggerTemplate: petsDoc})
+}
+
+func serve(t *testing.T, handler http.Handler, r *http.Request) (*httptest.ResponseRecorder, *Error) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		return w, nil
+	}
+
+	var result Error
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+
+	return w, &result
+}
+
+func TestMiddleware(t *testing.T) {
+	var body string
+
+	handler := Middleware("validate_test")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+	}))
+
+	t.Run("valid query and header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/pets?limit=10&order=asc&ids=1,2", nil)
+		r.Header.Set("X-Request-Id", "abc123")
+
+		w, result := serve(t, handler, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, result)
+	})
+
+	t.Run("invalid query and header", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/pets?limit=1000&order=random&ids=1,x", nil)
+		r.Header.Set("X-Request-Id", "xyz")
+
+		w, result := serve(t, handler, r)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, []FieldError{
+			{In: "query", Name: "limit", Message: "must be less than or equal to 100"},
+			{In: "query", Name: "order", Message: `must be one of "asc", "desc"`},
+			{In: "query", Name: "ids[1]", Message: "must be an integer"},
+			{In: "header", Name: "X-Request-Id", Message: `must match pattern "^[a-f0-9]+$"`},
+		}, result.Errors)
+	})
+
+	t.Run("missing required", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/pets", nil)
+
+		_, result := serve(t, handler, r)
+		require.NotNil(t, result)
+		assert.Equal(t, []FieldError{
+			{In: "query", Name: "limit", Message: "is required"},
+			{In: "header", Name: "X-Request-Id", Message: "is required"},
+		}, result.Errors)
+	})
+
+	t.Run("path", func(t *testing.T) {
+		_, result := serve(t, handler, httptest.NewRequest(http.MethodGet, "/api/v1/pets/abc", nil))
+		require.NotNil(t, result)
+		assert.Equal(t, []FieldError{{In: "path", Name: "id", Message: "must be an integer"}}, result.Errors)
+
+		w, result := serve(t, handler, httptest.NewRequest(http.MethodGet, "/api/v1/pets/mine", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, result)
+	})
+
+	t.Run("valid body", func(t *testing.T) {
+		payload := `{"id": 1, "name": "Rex", "status": "sold", "tags": [{"name": "dog"}]}`
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(payload))
+		r.Header.Set("Content-Type", "application/json")
+
+		w, result := serve(t, handler, r)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, result)
+		assert.Equal(t, payload, body)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"status": "lost", "tags": [{"name": 1}]}`))
+		r.Header.Set("Content-Type", "application/json")
+
+		_, result := serve(t, handler, r)
+		require.NotNil(t, result)
+		assert.Equal(t, "request validation failed", result.Message)
+		assert.Equal(t, []FieldError{
+			{In: "body", Name: "body.name", Message: "is required"},
+			{In: "body", Name: "body.status", Message: `must be one of "available", "sold"`},
+			{In: "body", Name: "body.tags[0].name", Message: "must be of type string"},
+		}, result.Errors)
+	})
+
+	t.Run("missing body", func(t *testing.T) {
+		_, result := serve(t, handler, httptest.NewRequest(http.MethodPost, "/api/v1/pets", nil))
+		require.NotNil(t, result)
+		assert.Equal(t, []FieldError{{In: "body", Name: "pet", Message: "is required"}}, result.Errors)
+	})
+
+	t.Run("undocumented", func(t *testing.T) {
+		for _, r := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, "/health", nil),
+			httptest.NewRequest(http.MethodDelete, "/api/v1/pets", nil),
+		} {
+			w, result := serve(t, handler, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Nil(t, result)
+		}
+	})
+}
+
+func TestMiddleware_NotRegistered(t *testing.T) {
+	handler := Middleware("validate_missing")(http.NotFoundHandler())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestMiddleware_RegisteredLater(t *testing.T) {
+	handler := Middleware("validate_later")(http.NotFoundHandler())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/pets", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	swag.Register("validate_later", &swag.Spec{InfoInstanceName: "validate_later", SwaggerTemplate: petsDoc})
+
+	w, result := serve(t, handler, httptest.NewRequest(http.MethodGet, "/api/v1/pets", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, result)
+	assert.Contains(t, result.Errors, FieldError{In: "query", Name: "limit", Message: "is required"})
+}
+
+func TestMiddleware_MaxBodySize(t *testing.T) {
+	handler := Middleware("validate_test", WithMaxBodySize(16))(http.NotFoundHandler())
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name": "a rather long name"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	w, result := serve(t, handler, r)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	require.NotNil(t, result)
+	assert.Equal(t, []FieldError{{In: "body", Name: "pet", Message: "http: request body too large"}}, result.Errors)
+
+	r = httptest.NewRequest(http.MethodPost, "/api/v1/pets", strings.NewReader(`{"name": "Rex"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	w, _ = serve(t, handler, r)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestValidator_Validate(t *testing.T) {
+	var handled *Error
+
+	validator, err := New("validate_test", WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err *Error) {
+		handled = err
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}))
+	require.NoError(t, err)
+
+	assert.NoError(t, validator.Validate(httptest.NewRequest(http.MethodGet, "/api/v1/pets/1", nil)))
+
+	err = validator.Validate(httptest.NewRequest(http.MethodGet, "/api/v1/pets/x", nil))
+	require.Error(t, err)
+	assert.Equal(t, "request validation failed: path id: must be an integer", err.Error())
+
+	w := httptest.NewRecorder()
+	validator.Handler(http.NotFoundHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/pets/x", nil))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	require.NotNil(t, handled)
+	assert.Len(t, handled.Errors, 1)
+}
@@ -0,0 +1,158 @@
+package swag
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+const (
+	webhookAttr  = "@webhook"
+	callbackAttr = "@callback"
+
+	// xWebhooksExtension holds the webhooks of the document by name, like the webhooks
+	// of an OpenAPI 3.1 document.
+	xWebhooksExtension = "x-webhooks"
+
+	// xCallbacksExtension holds the callbacks of an operation by name and expression, like
+	// the callbacks of an OpenAPI 3 operation.
+	xCallbacksExtension = "x-callbacks"
+)
+
+// WebhookProperties is the name and method of a webhook declared with @Webhook.
+type WebhookProperties struct {
+	Name       string
+	HTTPMethod string
+}
+
+// operationCallback is a callback of an operation declared with @Callback.
+type operationCallback struct {
+	name       string
+	expression string
+	webhook    string
+}
+
+var webhookPattern = regexp.MustCompile(`^([\w.\-]+)[[:blank:]]+\[(\w+)]$`)
+
+// ParseWebhookComment parses the name and method of a webhook, an outgoing request
+// described by the operation. E.g. @Webhook orderPaid [post].
+func (operation *Operation) ParseWebhookComment(commentLine string) error {
+	matches := webhookPattern.FindStringSubmatch(commentLine)
+	if len(matches) != 3 {
+		return fmt.Errorf("can not parse webhook comment \"%s\"", commentLine)
+	}
+
+	method := strings.ToUpper(matches[2])
+	if !findInSlice(routeMethods, method) {
+		return fmt.Errorf("invalid method: %s", matches[2])
+	}
+
+	operation.Webhooks = append(operation.Webhooks, WebhookProperties{
+		Name:       matches[1],
+		HTTPMethod: method,
+	})
+
+	return nil
+}
+
+// ParseCallbackComment parses a callback of the operation, calling the URL given by a
+// runtime expression with the request of a webhook.
+// E.g. @Callback onPaid {$request.body#/callbackUrl} orderPaid.
+func (operation *Operation) ParseCallbackComment(commentLine string) error {
+	fields := strings.Fields(commentLine)
+	if len(fields) != 3 {
+		return fmt.Errorf("@Callback needs a name, an expression and a webhook name")
+	}
+
+	operation.callbacks = append(operation.callbacks, operationCallback{
+		name:       fields[0],
+		expression: fields[1],
+		webhook:    fields[2],
+	})
+
+	return nil
+}
+
+// processWebhookOperation registers the webhooks declared by the operation, and keeps the
+// operation to resolve its callbacks once every webhook is known.
+func (parser *Parser) processWebhookOperation(operation *Operation) error {
+	if len(operation.Webhooks) > 0 && len(operation.callbacks) > 0 {
+		return fmt.Errorf("webhook %s can't have callbacks", operation.Webhooks[0].Name)
+	}
+
+	for _, webhook := range operation.Webhooks {
+		pathItem := parser.webhooks[webhook.Name]
+
+		op := refRouteMethodOp(&pathItem, webhook.HTTPMethod)
+		if *op != nil {
+			return fmt.Errorf("webhook %s %s is declared multiple times", webhook.HTTPMethod, webhook.Name)
+		}
+
+		*op = &operation.Operation
+
+		parser.operationPackages[*op] = operation.packagePath
+		parser.webhooks[webhook.Name] = pathItem
+	}
+
+	if len(operation.callbacks) > 0 {
+		parser.callbackOperations = append(parser.callbackOperations, operation)
+	}
+
+	return nil
+}
+
+// resolveWebhooks writes the webhooks to the document, and the callbacks to their
+// operations.
+func (parser *Parser) resolveWebhooks() error {
+	for _, operation := range parser.callbackOperations {
+		callbacks := make(map[string]map[string]spec.PathItem, len(operation.callbacks))
+
+		for _, callback := range operation.callbacks {
+			pathItem, ok := parser.webhooks[callback.webhook]
+			if !ok {
+				return fmt.Errorf("webhook %s is not declared, declare it with @Webhook", callback.webhook)
+			}
+
+			if callbacks[callback.name] == nil {
+				callbacks[callback.name] = make(map[string]spec.PathItem)
+			}
+
+			callbacks[callback.name][callback.expression] = pathItem
+		}
+
+		operation.AddExtension(xCallbacksExtension, callbacks)
+	}
+
+	if len(parser.webhooks) > 0 {
+		parser.swagger.AddExtension(xWebhooksExtension, parser.webhooks)
+	}
+
+	return nil
+}
+
+// webhookPathItems returns the path items of the webhooks of the document and of the
+// callbacks of the operation, if any. The path items are copies, decoded from the
+// extensions.
+func webhookPathItems(extensions spec.Extensions) []spec.PathItem {
+	var pathItems []spec.PathItem
+
+	var webhooks map[string]spec.PathItem
+	if decodeExtension(extensions, xWebhooksExtension, &webhooks) {
+		for _, pathItem := range webhooks {
+			pathItems = append(pathItems, pathItem)
+		}
+	}
+
+	var callbacks map[string]map[string]spec.PathItem
+	if decodeExtension(extensions, xCallbacksExtension, &callbacks) {
+		for _, expressions := range callbacks {
+			for _, pathItem := range expressions {
+				pathItems = append(pathItems, pathItem)
+			}
+		}
+	}
+
+	return pathItems
+}
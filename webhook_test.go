@@ -0,0 +1,78 @@
+package swag
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhooks(t *testing.T) {
+	t.Parallel()
+
+	searchDir := "testdata/webhooks"
+	p := New()
+	err := p.ParseAPI(searchDir, mainAPIFile, defaultParseDepth)
+	assert.NoError(t, err)
+	b, _ := json.MarshalIndent(p.swagger, "", "    ")
+	expected, err := os.ReadFile(filepath.Join(searchDir, "expected.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(b))
+
+	// the definitions only used by webhooks are kept
+	assert.Empty(t, p.PruneUnusedDefinitions().Dropped)
+
+	// and so are they once the document is read back from JSON
+	var document spec.Swagger
+	require.NoError(t, json.Unmarshal(b, &document))
+	assert.Empty(t, PruneDefinitions(&document))
+	assert.Len(t, webhookPathItems(document.Extensions), len(p.webhooks))
+}
+
+func TestOperation_ParseWebhookComment(t *testing.T) {
+	t.Parallel()
+
+	operation := NewOperation(nil)
+	require.NoError(t, operation.ParseComment("@Webhook order.paid [post]", nil))
+	require.NoError(t, operation.ParseComment("@Webhook orderPaid [PUT]", nil))
+	assert.Equal(t, []WebhookProperties{{Name: "order.paid", HTTPMethod: "POST"}, {Name: "orderPaid", HTTPMethod: "PUT"}}, operation.Webhooks)
+
+	assert.EqualError(t, operation.ParseComment("@Webhook orderPaid", nil), `can not parse webhook comment "orderPaid"`)
+	assert.EqualError(t, operation.ParseComment("@Webhook orderPaid [send]", nil), "invalid method: send")
+}
+
+func TestOperation_ParseCallbackComment(t *testing.T) {
+	t.Parallel()
+
+	p := New()
+	webhook := NewOperation(p)
+	require.NoError(t, webhook.ParseComment("@Webhook orderPaid [post]", nil))
+	require.NoError(t, p.processWebhookOperation(webhook))
+	assert.EqualError(t, p.processWebhookOperation(webhook), "webhook POST orderPaid is declared multiple times")
+
+	operation := NewOperation(p)
+	require.NoError(t, operation.ParseComment("@Callback onPaid {$request.body#/callbackUrl} orderPaid", nil))
+	require.NoError(t, operation.ParseComment("@Callback onPaid {$request.query.url} orderPaid", nil))
+	require.NoError(t, p.processWebhookOperation(operation))
+	require.NoError(t, p.resolveWebhooks())
+
+	callbacks := webhookPathItems(operation.Extensions)
+	require.Len(t, callbacks, 2)
+	expected, _ := json.Marshal(webhook.Operation)
+	actual, _ := json.Marshal(callbacks[0].Post)
+	assert.JSONEq(t, string(expected), string(actual))
+
+	assert.EqualError(t, operation.ParseComment("@Callback onPaid orderPaid", nil), "@Callback needs a name, an expression and a webhook name")
+
+	operation = NewOperation(p)
+	require.NoError(t, operation.ParseComment("@Callback onPaid {$request.body#/callbackUrl} missing", nil))
+	require.NoError(t, p.processWebhookOperation(operation))
+	assert.EqualError(t, p.resolveWebhooks(), "webhook missing is not declared, declare it with @Webhook")
+
+	require.NoError(t, operation.ParseComment("@Webhook orderShipped [post]", nil))
+	assert.EqualError(t, New().processWebhookOperation(operation), "webhook orderShipped can't have callbacks")
+}